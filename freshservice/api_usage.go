@@ -0,0 +1,182 @@
+package freshservice
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// idSegment matches the ids in an API path, which are replaced so that requests are grouped by endpoint.
+var idSegment = regexp.MustCompile(`/\d+(/|$)`)
+
+// apiUsage holds the calls made to FreshService by a connection and the API quota last reported in the responses.
+type apiUsage struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointUsage
+
+	quotaTotal     *int
+	quotaRemaining *int
+	quotaUsed      *int
+	quotaUpdatedAt time.Time
+}
+
+// endpointUsage holds the calls made to an endpoint (such as "tickets/{id}/tasks").
+type endpointUsage struct {
+	Endpoint      string
+	Requests      int
+	Retries       int
+	Throttled     int
+	Errors        int
+	TotalLatency  time.Duration
+	MaxLatency    time.Duration
+	LastStatus    int
+	LastRequestAt time.Time
+}
+
+// usageRow is a row of the freshservice_api_usage table.
+type usageRow struct {
+	endpointUsage
+	AverageLatencyMs   float64
+	MaxLatencyMs       float64
+	RateLimitTotal     *int
+	RateLimitRemaining *int
+	RateLimitUsed      *int
+	RateLimitUpdatedAt *time.Time
+}
+
+var (
+	apiUsages     = make(map[string]*apiUsage)
+	apiUsagesLock sync.Mutex
+)
+
+// connectionUsage returns the apiUsage of the named connection, usage is kept for the lifetime of the plugin process.
+func connectionUsage(connection string) *apiUsage {
+	apiUsagesLock.Lock()
+	defer apiUsagesLock.Unlock()
+
+	if u, ok := apiUsages[connection]; ok {
+		return u
+	}
+
+	u := &apiUsage{endpoints: make(map[string]*endpointUsage)}
+	apiUsages[connection] = u
+
+	return u
+}
+
+// endpoint returns the usage of the endpoint at path, creating it if required, the caller must hold the lock.
+func (u *apiUsage) endpoint(path string) *endpointUsage {
+	name := strings.Trim(idSegment.ReplaceAllString("/"+path, "/{id}$1"), "/")
+
+	e, ok := u.endpoints[name]
+	if !ok {
+		e = &endpointUsage{Endpoint: name}
+		u.endpoints[name] = e
+	}
+
+	return e
+}
+
+// retry counts a request to path which is being sent again.
+func (u *apiUsage) retry(path string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.endpoint(path).Retries++
+}
+
+// record counts an attempt at a request to path, res is nil if no response was received.
+func (u *apiUsage) record(path string, res *http.Response, latency time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	e := u.endpoint(path)
+	e.Requests++
+	e.TotalLatency += latency
+	if latency > e.MaxLatency {
+		e.MaxLatency = latency
+	}
+	e.LastRequestAt = time.Now()
+
+	if res == nil {
+		e.Errors++
+		e.LastStatus = 0
+		return
+	}
+
+	e.LastStatus = res.StatusCode
+	if res.StatusCode == http.StatusTooManyRequests {
+		e.Throttled++
+	} else if res.StatusCode < 200 || res.StatusCode > 299 {
+		e.Errors++
+	}
+
+	if v, ok := headerInt(res.Header, "X-Ratelimit-Remaining"); ok {
+		u.quotaRemaining = &v
+		u.quotaUpdatedAt = time.Now()
+		if total, ok := headerInt(res.Header, "X-Ratelimit-Total"); ok {
+			u.quotaTotal = &total
+		}
+		if used, ok := headerInt(res.Header, "X-Ratelimit-Used-Currentrequest"); ok {
+			u.quotaUsed = &used
+		}
+	}
+}
+
+// rows returns the usage of every endpoint, ordered by endpoint.
+func (u *apiUsage) rows() []usageRow {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var updatedAt *time.Time
+	if !u.quotaUpdatedAt.IsZero() {
+		t := u.quotaUpdatedAt
+		updatedAt = &t
+	}
+
+	rows := make([]usageRow, 0, len(u.endpoints))
+	for _, e := range u.endpoints {
+		r := usageRow{
+			endpointUsage:      *e,
+			MaxLatencyMs:       float64(e.MaxLatency) / float64(time.Millisecond),
+			RateLimitTotal:     u.quotaTotal,
+			RateLimitRemaining: u.quotaRemaining,
+			RateLimitUsed:      u.quotaUsed,
+			RateLimitUpdatedAt: updatedAt,
+		}
+		if e.Requests > 0 {
+			r.AverageLatencyMs = float64(e.TotalLatency) / float64(e.Requests) / float64(time.Millisecond)
+		}
+		rows = append(rows, r)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Endpoint < rows[j].Endpoint
+	})
+
+	return rows
+}
+
+func headerInt(header http.Header, name string) (int, bool) {
+	v, err := strconv.Atoi(header.Get(name))
+	return v, err == nil
+}
+
+// usageTransport records every attempt at a request in the apiUsage of the connection.
+type usageTransport struct {
+	base    http.RoundTripper
+	usage   *apiUsage
+	apiPath string
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	t.usage.record(strings.TrimPrefix(req.URL.Path, t.apiPath), res, time.Since(start))
+
+	return res, err
+}
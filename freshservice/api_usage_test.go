@@ -0,0 +1,68 @@
+package freshservice
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestApiUsage(t *testing.T) {
+	p := startPlugin(t, "")
+
+	var throttled int32
+	p.api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		w.Header().Set("X-Ratelimit-Total", "100")
+		w.Header().Set("X-Ratelimit-Remaining", "42")
+		w.Header().Set("X-Ratelimit-Used-CurrentRequest", "1")
+
+		// throttle the first request for tickets, which is then retried
+		if r.URL.Path == "/api/v2/tickets" && atomic.CompareAndSwapInt32(&throttled, 0, 1) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return true
+		}
+		return false
+	}
+
+	p.mustQuery(t, "freshservice_ticket", nil, 0)
+	p.mustQuery(t, "freshservice_ticket", quals{"id": 1}, 0)
+	p.mustQuery(t, "freshservice_ticket_task", quals{"ticket_id": 1}, 0)
+
+	rows := p.mustQuery(t, "freshservice_api_usage", nil, 0)
+
+	usage := make(map[string]row)
+	for _, r := range rows {
+		usage[r["endpoint"].(string)] = r
+	}
+
+	want := map[string]row{
+		"tickets":            {"requests": int64(2), "retries": int64(1), "throttled": int64(1), "errors": int64(0), "last_status": int64(200)},
+		"tickets/{id}":       {"requests": int64(1), "retries": int64(0), "throttled": int64(0)},
+		"tickets/{id}/tasks": {"requests": int64(1)},
+	}
+	for endpoint, columns := range want {
+		r, ok := usage[endpoint]
+		if !ok {
+			t.Errorf("expected usage of endpoint %s, got %v", endpoint, rows)
+			continue
+		}
+		for column, value := range columns {
+			if r[column] != value {
+				t.Errorf("%s: expected %s to be %v, got %v", endpoint, column, value, r[column])
+			}
+		}
+		if r["rate_limit_total"] != int64(100) || r["rate_limit_remaining"] != int64(42) || r["rate_limit_used_current_request"] != int64(1) {
+			t.Errorf("%s: expected the rate limit headers to be reported, got %v", endpoint, r)
+		}
+		if r["last_request_at"] == nil || r["average_latency_ms"] == nil {
+			t.Errorf("%s: expected the timing of requests to be reported, got %v", endpoint, r)
+		}
+	}
+
+	// querying the usage must not make any requests to FreshService
+	p.api.Reset()
+	p.mustQuery(t, "freshservice_api_usage", nil, 0)
+	if requests := p.api.Requests(); len(requests) != 0 {
+		t.Errorf("expected no requests to be sent, got %v", requests)
+	}
+}
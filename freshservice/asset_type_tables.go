@@ -0,0 +1,202 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"regexp"
+	"strings"
+)
+
+// assetTypeTablePrefix is prepended to the name of an asset type to form the name of its table.
+const assetTypeTablePrefix = "freshservice_asset_"
+
+// typeFieldSuffix matches the id of the asset type which FreshService appends to the name of a type field (such as
+// serial_number_4000123), which is removed from the column name.
+var typeFieldSuffix = regexp.MustCompile(`_\d+$`)
+
+// assetTypeField is a field specific to an asset type, the value of the field is held in the type_fields of an asset.
+type assetTypeField struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Label     string `json:"label"`
+	FieldType string `json:"field_type"`
+}
+
+// assetTypeFieldGroups holds the fields of an asset type, grouped under a header such as "Hardware".
+type assetTypeFieldGroups struct {
+	Collection []struct {
+		FieldHeader string           `json:"field_header"`
+		Fields      []assetTypeField `json:"fields"`
+	} `json:"asset_type_fields"`
+}
+
+// assetTypeFieldColumnTypes maps the field_type of a type field to the type of its column, other field types (such as
+// text & dropdowns) are strings.
+var assetTypeFieldColumnTypes = map[string]proto.ColumnType{
+	"number":   proto.ColumnType_INT,
+	"decimal":  proto.ColumnType_DOUBLE,
+	"checkbox": proto.ColumnType_BOOL,
+	"date":     proto.ColumnType_TIMESTAMP,
+}
+
+// addAssetTypeTables adds a table for every asset type (such as freshservice_asset_laptop) with a column for each
+// field of the type and of its parent types. No tables are added when the asset types can't be read, the table of a
+// type is added without its type field columns when its fields can't be read.
+func addAssetTypeTables(ctx context.Context, d *plugin.TableMapData, tables map[string]*plugin.Table) {
+	client, err := connectionClient(ctx, d.Connection, d.ConnectionCache)
+	if err != nil {
+		plugin.Logger(ctx).Warn("freshservice.addAssetTypeTables", "connection_error", err)
+		return
+	}
+
+	assetTypes, err := listAll(ctx, client, "asset_types", func(types *fs.AssetTypes) []fs.AssetType {
+		return types.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Warn("freshservice.addAssetTypeTables", "query_error", fmt.Errorf("unable to obtain asset types: %w", err))
+		return
+	}
+
+	byId := make(map[int]fs.AssetType, len(assetTypes))
+	for _, t := range assetTypes {
+		byId[t.ID] = t
+	}
+
+	fields := make(map[int][]assetTypeField)
+	for _, t := range assetTypes {
+		groups := new(assetTypeFieldGroups)
+		if _, err = client.list(ctx, fmt.Sprintf("asset_types/%d/fields", t.ID), nil, groups); err != nil {
+			plugin.Logger(ctx).Warn("freshservice.addAssetTypeTables", "query_error", fmt.Errorf("unable to obtain fields of asset type %d: %w", t.ID, err))
+			continue
+		}
+		for _, g := range groups.Collection {
+			fields[t.ID] = append(fields[t.ID], g.Fields...)
+		}
+	}
+
+	for _, t := range assetTypes {
+		// the fields of a type include those inherited from its parent types
+		var inherited []assetTypeField
+		for id, seen := t.ID, make(map[int]bool); id != 0 && !seen[id]; id = byId[id].ParentAssetTypeID {
+			seen[id] = true
+			inherited = append(inherited, fields[id]...)
+		}
+
+		name := assetTypeTableName(t.Name)
+		if _, exists := tables[name]; exists || name == assetTypeTablePrefix {
+			name = fmt.Sprintf("%s_%d", strings.TrimSuffix(name, "_"), t.ID)
+		}
+
+		tables[name] = tableAssetOfType(name, t, inherited)
+	}
+}
+
+// assetTypeTableName returns the name of the table of the asset type with the given name, such as
+// freshservice_asset_network_device for "Network Device".
+func assetTypeTableName(name string) string {
+	return assetTypeTablePrefix + columnName(name)
+}
+
+// assetItem is an asset, go-freshservice doesn't decode the type fields of an asset (or the workspace it belongs to).
+type assetItem struct {
+	fs.Asset
+	WorkspaceID *int                   `json:"workspace_id"`
+	TypeFields  map[string]interface{} `json:"type_fields"`
+}
+
+type assetItems struct {
+	Collection []assetItem `json:"assets"`
+}
+
+// assetOptions lists (or gets) assets, with the type_fields of the assets when include is "type_fields" and restricted
+// to a single asset type by filter.
+type assetOptions struct {
+	fs.ListAssetsOptions
+	workspaceOptions
+	Include string `url:"include,omitempty"`
+	Filter  string `url:"filter,omitempty"`
+}
+
+// tableAssetOfType is a table of the assets of a single asset type, with the columns of freshservice_asset and a
+// column for each of fields.
+func tableAssetOfType(name string, assetType fs.AssetType, fields []assetTypeField) *plugin.Table {
+	return withDomainColumn(&plugin.Table{
+		Name:        name,
+		Description: fmt.Sprintf("Obtain information about Assets of the %s asset type (ID %d), with a column for each field of the asset type.", assetType.Name, assetType.ID),
+		List: &plugin.ListConfig{
+			Hydrate: listAssetsOfType(assetType.ID),
+			KeyColumns: []*plugin.KeyColumn{
+				workspaceIdKeyColumn(),
+			},
+		},
+		Columns: append(assetColumns(), typeFieldColumns(fields)...),
+	})
+}
+
+// typeFieldColumns returns a column for each of fields, named after the field without the id of the asset type (such
+// as serial_number). The full name of the field is used when this would clash with another column.
+func typeFieldColumns(fields []assetTypeField) []*plugin.Column {
+	seen := make(map[string]bool)
+	for _, c := range assetColumns() {
+		seen[c.Name] = true
+	}
+	seen[domainColumn().Name] = true
+
+	var columns []*plugin.Column
+	added := make(map[string]bool)
+	for _, f := range fields {
+		// only type fields have the id of the asset type appended, other fields are columns of freshservice_asset
+		if !typeFieldSuffix.MatchString(f.Name) || added[f.Name] {
+			continue
+		}
+		added[f.Name] = true
+
+		name := columnName(typeFieldSuffix.ReplaceAllString(f.Name, ""))
+		if seen[name] {
+			name = columnName(f.Name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		columnType, ok := assetTypeFieldColumnTypes[f.FieldType]
+		if !ok {
+			columnType = proto.ColumnType_STRING
+		}
+
+		columns = append(columns, &plugin.Column{
+			Name:        name,
+			Description: fmt.Sprintf("%s (%s).", f.Label, f.Name),
+			Type:        columnType,
+			Transform:   transform.FromField("TypeFields." + f.Name),
+		})
+	}
+
+	return columns
+}
+
+// listAssetsOfType returns the List hydrate of the table of an asset type.
+func listAssetsOfType(assetTypeID int) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		client, err := connect(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listAssetsOfType", "connection_error", err)
+			return nil, fmt.Errorf("unable to create FreshService client: %v", err)
+		}
+
+		filter := assetOptions{Include: "type_fields", Filter: fmt.Sprintf(`"asset_type_id:%d"`, assetTypeID)}
+
+		err = streamAssets(ctx, d, client, filter)
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listAssetsOfType", "query_error", err)
+			return nil, fmt.Errorf("unable to obtain assets of asset type %d: %w", assetTypeID, err)
+		}
+
+		return nil, nil
+	}
+}
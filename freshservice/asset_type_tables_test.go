@@ -0,0 +1,137 @@
+package freshservice
+
+import (
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"strings"
+	"testing"
+)
+
+func TestTypeFieldColumns(t *testing.T) {
+	fields := []assetTypeField{
+		{Name: "asset_tag", Label: "Asset Tag", FieldType: "text"},
+		{Name: "serial_number_4000123", Label: "Serial Number", FieldType: "text"},
+		{Name: "cost_4000123", Label: "Cost", FieldType: "decimal"},
+		{Name: "name_4000124", Label: "Model Name", FieldType: "text"},
+		{Name: "serial_number_4000123", Label: "Duplicate", FieldType: "text"},
+	}
+
+	want := map[string]proto.ColumnType{
+		"serial_number": proto.ColumnType_STRING,
+		"cost":          proto.ColumnType_DOUBLE,
+		"name_4000124":  proto.ColumnType_STRING,
+	}
+
+	columns := typeFieldColumns(fields)
+	if len(columns) != len(want) {
+		t.Errorf("expected %d columns, got %d", len(want), len(columns))
+	}
+	for _, c := range columns {
+		if columnType, ok := want[c.Name]; !ok || c.Type != columnType {
+			t.Errorf("unexpected column %s of type %v", c.Name, c.Type)
+		}
+	}
+}
+
+func TestAssetTypeTables(t *testing.T) {
+	p := startPlugin(t, "asset_type_tables = true")
+
+	schema, err := p.client.GetSchema(p.connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string]proto.ColumnType{
+		"freshservice_asset_hardware": {
+			"serial_number":        proto.ColumnType_STRING,
+			"cost":                 proto.ColumnType_DOUBLE,
+			"warranty_expiry_date": proto.ColumnType_TIMESTAMP,
+			"ram":                  proto.ColumnType_INT,
+			"domain":               proto.ColumnType_STRING,
+		},
+		// printers inherit the fields of hardware
+		"freshservice_asset_printer": {
+			"serial_number":    proto.ColumnType_STRING,
+			"ram":              proto.ColumnType_INT,
+			"color":            proto.ColumnType_BOOL,
+			"pages_per_minute": proto.ColumnType_INT,
+			"name_2":           proto.ColumnType_STRING,
+			"name":             proto.ColumnType_STRING,
+		},
+	}
+	for table, columns := range want {
+		s, ok := schema.Schema[table]
+		if !ok {
+			t.Errorf("expected table %s", table)
+			continue
+		}
+		types := make(map[string]proto.ColumnType)
+		for _, c := range s.Columns {
+			types[c.Name] = c.Type
+		}
+		for name, columnType := range columns {
+			if types[name] != columnType {
+				t.Errorf("%s: expected column %s of type %v, got %v", table, name, columnType, types[name])
+			}
+		}
+	}
+
+	p.api.Reset()
+	rows := p.mustQuery(t, "freshservice_asset_printer", nil, 0)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 printer, got %d", len(rows))
+	}
+	assertRow(t, rows, row{
+		"id":               int64(52),
+		"name":             "Printer",
+		"serial_number":    "SN-1002",
+		"cost":             float64(300),
+		"color":            true,
+		"pages_per_minute": int64(40),
+	})
+
+	for _, r := range p.api.RequestsTo("assets") {
+		q := r.Query()
+		if q.Get("filter") != `"asset_type_id:2"` || q.Get("include") != "type_fields" {
+			t.Errorf("expected the assets to be filtered by asset type with their type fields, got %s", r.RawQuery)
+		}
+	}
+
+	assertRow(t, p.mustQuery(t, "freshservice_asset_hardware", nil, 0), row{
+		"id":                   int64(51),
+		"serial_number":        "SN-1001",
+		"warranty_expiry_date": ts("2025-01-01T00:00:00Z"),
+		"ram":                  int64(16),
+	})
+}
+
+func TestAssetTypeTablesOptional(t *testing.T) {
+	p := startPlugin(t, "")
+
+	schema, err := p.client.GetSchema(p.connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range schema.Schema {
+		if _, static := tableMap()[name]; !static && strings.HasPrefix(name, assetTypeTablePrefix) {
+			t.Errorf("expected no asset type tables, got %s", name)
+		}
+	}
+}
+
+func TestAssetInclude(t *testing.T) {
+	tests := []struct {
+		columns []string
+		want    string
+	}{
+		{columns: []string{"id", "name"}, want: ""},
+		{columns: []string{"id", "type_fields"}, want: "type_fields"},
+	}
+
+	for _, tt := range tests {
+		d := &plugin.QueryData{QueryContext: &plugin.QueryContext{Columns: tt.columns}}
+		if got := assetInclude(d); got != tt.want {
+			t.Errorf("%v: expected include %q, got %q", tt.columns, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package freshservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+
+	// redactedDomain replaces the domain of the FreshService instance in recorded urls & bodies.
+	redactedDomain = "example.freshservice.com"
+	redactedToken  = "REDACTED"
+	// redactedText replaces the free text of descriptions & conversations, which often mention people by name.
+	redactedText = "Redacted text"
+)
+
+// recordedHeaders are the only response headers written to a cassette, all others are dropped.
+var recordedHeaders = []string{"Content-Type", "Link", "Retry-After"}
+
+// personNameKeys hold the name of a person wherever they appear in a response.
+var personNameKeys = map[string]bool{
+	"first_name": true,
+	"last_name":  true,
+}
+
+// freeTextKeys hold text written by people (such as the description of a ticket or the body of a reply), the names
+// within them can't be told apart from the rest of the text so the whole value is redacted.
+var freeTextKeys = map[string]bool{
+	"description":      true,
+	"description_text": true,
+	"body":             true,
+	"body_text":        true,
+}
+
+// emailKeys mark an object as describing a person, in which case its "name" is also redacted.
+var emailKeys = []string{"email", "primary_email"}
+
+var (
+	emailPattern         = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactedEmailPattern = regexp.MustCompile(`^user\d+@example\.com$`)
+)
+
+// cassette holds the interactions with the FreshService API recorded for a connection, paths are relative to the
+// api base url (for example "tickets?page=1&per_page=100") so that a cassette can be replayed against any domain.
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type recordedResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    json.RawMessage     `json:"body,omitempty"`
+}
+
+// cassetteTransport validates the cassette settings of the PluginConfig and wraps base so that interactions are
+// recorded to, or replayed from, the cassette file. base is returned unchanged when no cassette is configured.
+func cassetteTransport(config PluginConfig, baseUrl *url.URL, base http.RoundTripper) (http.RoundTripper, error) {
+	mode := ""
+	if config.CassetteMode != nil {
+		mode = strings.ToLower(*config.CassetteMode)
+	}
+
+	file := ""
+	if config.CassetteFile != nil {
+		file = *config.CassetteFile
+	}
+
+	switch {
+	case mode == "" && file == "":
+		return base, nil
+	case file == "":
+		return nil, fmt.Errorf("configuration option 'cassette_file' must be set when 'cassette_mode' is set")
+	case mode == cassetteRecord:
+		return &recordingTransport{base: base, recorder: cassetteRecorder(file, baseUrl)}, nil
+	case mode == cassetteReplay:
+		c, err := loadCassette(file)
+		if err != nil {
+			return nil, err
+		}
+		return &replayTransport{baseUrl: baseUrl, cassette: c, used: make(map[*interaction]bool)}, nil
+	default:
+		return nil, fmt.Errorf("configuration option 'cassette_mode' must be '%s' or '%s', got '%s'", cassetteRecord, cassetteReplay, mode)
+	}
+}
+
+func loadCassette(file string) (*cassette, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette '%s': %v", file, err)
+	}
+
+	c := new(cassette)
+	if err = json.Unmarshal(raw, c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette '%s': %v", file, err)
+	}
+
+	return c, nil
+}
+
+// relativePath returns the path & query of u relative to baseUrl, with the query parameters sorted.
+func relativePath(baseUrl *url.URL, u *url.URL) string {
+	p := strings.TrimPrefix(u.Path, baseUrl.Path)
+	if u.RawQuery == "" {
+		return p
+	}
+
+	return p + "?" + u.Query().Encode()
+}
+
+// recorder writes redacted interactions to a cassette file, it is shared by all clients recording to the same file
+// so that reconnecting appends to the cassette rather than overwriting it.
+type recorder struct {
+	mu       sync.Mutex
+	file     string
+	baseUrl  *url.URL
+	token    string
+	cassette cassette
+	emails   map[string]string
+	names    map[string]string
+}
+
+var (
+	recorders     = make(map[string]*recorder)
+	recordersLock sync.Mutex
+)
+
+func cassetteRecorder(file string, baseUrl *url.URL) *recorder {
+	recordersLock.Lock()
+	defer recordersLock.Unlock()
+
+	if r, ok := recorders[file]; ok {
+		r.mu.Lock()
+		r.baseUrl = baseUrl
+		r.mu.Unlock()
+		return r
+	}
+
+	r := &recorder{
+		file:    file,
+		baseUrl: baseUrl,
+		emails:  make(map[string]string),
+		names:   make(map[string]string),
+	}
+	recorders[file] = r
+
+	return r
+}
+
+// record appends the interaction to the cassette, the whole cassette is rewritten so the file is always complete.
+func (r *recorder) record(req *http.Request, res *http.Response, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the token is the username of the basic auth credentials, it is taken from each request as it may be rotated
+	if token, _, ok := req.BasicAuth(); ok {
+		r.token = token
+	}
+
+	i := &interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			Path:   relativePath(r.baseUrl, r.redactUrl(req.URL)),
+		},
+		Response: recordedResponse{
+			Status:  res.StatusCode,
+			Headers: make(map[string][]string),
+		},
+	}
+
+	for _, h := range recordedHeaders {
+		for _, v := range res.Header.Values(h) {
+			i.Response.Headers[h] = append(i.Response.Headers[h], r.redactString(v))
+		}
+	}
+
+	if len(bytes.TrimSpace(body)) > 0 {
+		redacted, err := r.redactBody(body)
+		if err != nil {
+			return err
+		}
+		i.Response.Body = redacted
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+
+	out, err := marshalJSON(r.cassette, "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode cassette: %v", err)
+	}
+
+	if err = os.WriteFile(r.file, out, 0600); err != nil {
+		return fmt.Errorf("unable to write cassette '%s': %v", r.file, err)
+	}
+
+	return nil
+}
+
+// redactBody masks tokens, emails and the names of people in a response body, bodies which are not JSON are stored
+// as a (redacted) JSON string.
+func (r *recorder) redactBody(body []byte) (json.RawMessage, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return marshalJSON(r.redactString(string(body)), "")
+	}
+
+	return marshalJSON(r.redactValue(v), "")
+}
+
+// redactUrl returns a copy of u with the values of its query parameters redacted.
+func (r *recorder) redactUrl(u *url.URL) *url.URL {
+	redacted := *u
+
+	q := u.Query()
+	for _, values := range q {
+		for i, v := range values {
+			values[i] = r.redactString(v)
+		}
+	}
+	redacted.RawQuery = q.Encode()
+
+	return &redacted
+}
+
+func (r *recorder) redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		isPerson := false
+		for _, k := range emailKeys {
+			if _, ok := value[k]; ok {
+				isPerson = true
+			}
+		}
+
+		// keys are redacted in order so that the same response is always given the same placeholders
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			child := value[k]
+			if s, ok := child.(string); ok && s != "" && (personNameKeys[k] || (isPerson && k == "name")) {
+				value[k] = r.redactName(s)
+				continue
+			}
+			if s, ok := child.(string); ok && s != "" && freeTextKeys[k] {
+				value[k] = redactedText
+				continue
+			}
+			value[k] = r.redactValue(child)
+		}
+		return value
+	case []interface{}:
+		for i, child := range value {
+			value[i] = r.redactValue(child)
+		}
+		return value
+	case string:
+		return r.redactString(value)
+	default:
+		return v
+	}
+}
+
+// redactString masks the token, domain & any email addresses in s, each email is consistently replaced with the same
+// placeholder so that relationships between records (and filters on email) are preserved.
+func (r *recorder) redactString(s string) string {
+	if r.token != "" {
+		s = strings.ReplaceAll(s, r.token, redactedToken)
+	}
+	if r.baseUrl != nil && r.baseUrl.Host != "" {
+		s = strings.ReplaceAll(s, r.baseUrl.Host, redactedDomain)
+	}
+
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		if redactedEmailPattern.MatchString(email) {
+			return email
+		}
+
+		key := strings.ToLower(email)
+		if _, ok := r.emails[key]; !ok {
+			r.emails[key] = fmt.Sprintf("user%d@example.com", len(r.emails)+1)
+		}
+		return r.emails[key]
+	})
+}
+
+func (r *recorder) redactName(name string) string {
+	if _, ok := r.names[name]; !ok {
+		r.names[name] = fmt.Sprintf("Person %d", len(r.names)+1)
+	}
+	return r.names[name]
+}
+
+// marshalJSON encodes v without escaping HTML characters, keeping urls & bodies in a cassette readable.
+func marshalJSON(v interface{}, indent string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// recordingTransport records every response received from base.
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder *recorder
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = t.recorder.record(req, res, body); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// replayTransport answers requests from a cassette without contacting the FreshService API. Interactions are replayed
+// in the order they were recorded, once all matching interactions have been used the last one is repeated. The emails
+// in recorded paths are masked (and the mapping isn't recorded), so a replayed query must filter on the placeholders.
+type replayTransport struct {
+	mu       sync.Mutex
+	baseUrl  *url.URL
+	cassette *cassette
+	used     map[*interaction]bool
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	i := t.match(req.Method, relativePath(t.baseUrl, req.URL))
+	if i == nil {
+		msg := fmt.Sprintf("no interaction recorded in the cassette for %s %s", req.Method, relativePath(t.baseUrl, req.URL))
+		if hasUnredactedEmail(req.URL) {
+			msg += ", emails are masked in a cassette so filter on the placeholder (such as user1@example.com) instead"
+		}
+		return nil, errors.New(msg)
+	}
+
+	header := make(http.Header)
+	for k, values := range i.Response.Headers {
+		for _, v := range values {
+			header.Add(k, v)
+		}
+	}
+
+	body := []byte(i.Response.Body)
+	var s string
+	if json.Unmarshal(body, &s) == nil {
+		body = []byte(s)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.Status, http.StatusText(i.Response.Status)),
+		StatusCode:    i.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// hasUnredactedEmail reports whether the query of u holds an email which is not a cassette placeholder.
+func hasUnredactedEmail(u *url.URL) bool {
+	for _, values := range u.Query() {
+		for _, v := range values {
+			for _, email := range emailPattern.FindAllString(v, -1) {
+				if !redactedEmailPattern.MatchString(email) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (t *replayTransport) match(method string, path string) *interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var last *interaction
+	for _, i := range t.cassette.Interactions {
+		if i.Request.Method != method || i.Request.Path != path {
+			continue
+		}
+		if !t.used[i] {
+			t.used[i] = true
+			return i
+		}
+		last = i
+	}
+
+	return last
+}
@@ -0,0 +1,102 @@
+package freshservice
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func cassetteConfig(mode string, file string) string {
+	return fmt.Sprintf("cassette_mode = \"%s\"\ncassette_file = \"%s\"", mode, file)
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cassette.json")
+
+	recording := startPlugin(t, cassetteConfig(cassetteRecord, file))
+	recording.api.MaxPerPage = 2
+
+	recorded := recording.mustQuery(t, "freshservice_ticket", nil, 0)
+	recording.mustQuery(t, "freshservice_agent", quals{"id": 1}, 0)
+	recording.mustQuery(t, "freshservice_requester", quals{"email": "grace@example.com"}, 0)
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("cassette was not written: %v", err)
+	}
+	cassette := string(raw)
+
+	apiUrl, _ := url.Parse(recording.api.URL)
+	for _, secret := range []string{testToken, apiUrl.Host, "grace@example.com", "linus@example.com", "Ada", "Lovelace", "Grace", "Hopper", "I cannot login"} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("expected '%s' to be redacted from the cassette", secret)
+		}
+	}
+	for _, kept := range []string{"Cannot login", "user1@example.com", redactedDomain, "page=2"} {
+		if !strings.Contains(cassette, kept) {
+			t.Errorf("expected the cassette to contain '%s'", kept)
+		}
+	}
+
+	replaying := startPlugin(t, cassetteConfig(cassetteReplay, file))
+
+	replayed := replaying.mustQuery(t, "freshservice_ticket", nil, 0)
+	if len(replayed) != len(recorded) {
+		t.Errorf("expected %d rows to be replayed, got %d", len(recorded), len(replayed))
+	}
+	assertRow(t, replayed, row{"id": int64(1), "subject": "Cannot login", "description_text": redactedText, "email": "user1@example.com"})
+
+	agents := replaying.mustQuery(t, "freshservice_agent", quals{"id": 1}, 0)
+	assertRow(t, agents, row{"id": int64(1), "first_name": "Person 1", "job_title": "Engineer"})
+
+	requesters := replaying.mustQuery(t, "freshservice_requester", quals{"email": "user1@example.com"}, 0)
+	if len(requesters) != 1 {
+		t.Errorf("expected the filtered requester to be replayed, got %d rows", len(requesters))
+	}
+
+	_, err = replaying.query(t, "freshservice_requester", quals{"email": "grace@example.com"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "user1@example.com") {
+		t.Errorf("expected a filter on an unmasked email to suggest the placeholder, got %v", err)
+	}
+
+	if requests := replaying.api.Requests(); len(requests) != 0 {
+		t.Errorf("expected no requests to be sent whilst replaying, got %v", requests)
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	file, err := filepath.Abs(filepath.Join("testdata", "cassettes", "ticket.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := startPlugin(t, cassetteConfig(cassetteReplay, file))
+
+	rows := p.mustQuery(t, "freshservice_ticket", nil, 0)
+	if len(rows) != 3 {
+		t.Errorf("expected 3 rows, got %d", len(rows))
+	}
+	assertRow(t, rows, row{"id": int64(3), "subject": "Printer jam", "status_desc": "Closed"})
+
+	_, err = p.query(t, "freshservice_agent", nil, 0)
+	if err == nil || !strings.Contains(err.Error(), "no interaction recorded") {
+		t.Errorf("expected a request missing from the cassette to fail, got %v", err)
+	}
+}
+
+func TestCassetteConfig(t *testing.T) {
+	for _, config := range []string{
+		`cassette_mode = "replay"`,
+		`cassette_mode = "rewind"` + "\n" + `cassette_file = "cassette.json"`,
+		cassetteConfig(cassetteReplay, filepath.Join(t.TempDir(), "missing.json")),
+	} {
+		p := startPlugin(t, config)
+
+		if _, err := p.query(t, "freshservice_agent", nil, 0); err == nil {
+			t.Errorf("expected an invalid cassette config to fail: %s", config)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/go-querystring/query"
+	retryHttp "github.com/hashicorp/go-retryablehttp"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetryAttempts = 5
+	defaultMaxRetryWait     = 60 * time.Second
+	minRetryWait            = time.Second
+
+	// maxErrorBodySize is the most of an error response read to describe the error.
+	maxErrorBodySize = 64 * 1024
+)
+
+// apiClient is a thin wrapper around the FreshService REST API, responses are decoded into the go-freshservice models.
+type apiClient struct {
+	client       *retryHttp.Client
+	baseUrl      *url.URL
+	token        tokenSource
+	pagePrefetch int
+}
+
+// newApiClient creates an apiClient for the REST API at baseUrl, retrying in line with the PluginConfig, throttling
+// every request through rl and recording it in usage.
+func newApiClient(ctx context.Context, baseUrl *url.URL, token tokenSource, config PluginConfig, rl *rateLimiter, usage *apiUsage) (*apiClient, error) {
+	attempts := defaultMaxRetryAttempts
+	if config.MaxRetryAttempts != nil && *config.MaxRetryAttempts > 0 {
+		attempts = *config.MaxRetryAttempts
+	}
+
+	maxWait := defaultMaxRetryWait
+	if config.MaxRetryWait != nil && *config.MaxRetryWait > 0 {
+		maxWait = time.Duration(*config.MaxRetryWait) * time.Second
+	}
+
+	prefetch := 0
+	if config.PagePrefetch != nil && *config.PagePrefetch > 0 {
+		prefetch = *config.PagePrefetch
+	}
+
+	httpClient, err := newHttpClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = &usageTransport{base: httpClient.Transport, usage: usage, apiPath: baseUrl.Path}
+	httpClient.Transport = rl.transport(httpClient.Transport)
+
+	httpClient.Transport, err = cassetteTransport(config, baseUrl, httpClient.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	// a replayed cassette always returns the same response, so there is no point retrying
+	if _, ok := httpClient.Transport.(*replayTransport); ok {
+		attempts = 1
+	}
+
+	httpClient.Transport = newLoggingTransport(config, baseUrl, httpClient.Transport)
+
+	return &apiClient{
+		client: &retryHttp.Client{
+			HTTPClient:   httpClient,
+			RetryWaitMin: minRetryWait,
+			RetryWaitMax: maxWait,
+			RetryMax:     attempts - 1,
+			CheckRetry:   checkRetry,
+			Backoff:      backoff,
+			ErrorHandler: retryHttp.PassthroughErrorHandler,
+			RequestLogHook: func(_ retryHttp.Logger, req *http.Request, attempt int) {
+				if attempt > 0 {
+					usage.retry(strings.TrimPrefix(req.URL.Path, baseUrl.Path))
+				}
+			},
+		},
+		baseUrl:      baseUrl,
+		token:        token,
+		pagePrefetch: prefetch,
+	}, nil
+}
+
+// get obtains a single resource from path, unwrapping it from the root element of the response into out.
+func (c *apiClient) get(ctx context.Context, path string, root string, out interface{}) error {
+	return c.getWith(ctx, path, nil, root, out)
+}
+
+// getWith is get with the query string opt (an options struct or pre-encoded url.Values), such as to include
+// additional attributes of the resource.
+func (c *apiClient) getWith(ctx context.Context, path string, opt interface{}, root string, out interface{}) error {
+	wrapper := make(map[string]json.RawMessage)
+	if _, err := c.do(ctx, path, opt, &wrapper); err != nil {
+		return err
+	}
+
+	if raw, ok := wrapper[root]; ok {
+		return json.Unmarshal(raw, out)
+	}
+
+	return fmt.Errorf("response from '%s' did not contain '%s'", path, root)
+}
+
+// list obtains a collection from path, filtered and paginated by opt (an options struct or pre-encoded url.Values),
+// the response is decoded into out.
+func (c *apiClient) list(ctx context.Context, path string, opt interface{}, out interface{}) (*http.Response, error) {
+	return c.do(ctx, path, opt, out)
+}
+
+func (c *apiClient) do(ctx context.Context, path string, opt interface{}, out interface{}) (*http.Response, error) {
+	dest := c.baseUrl.JoinPath(path)
+
+	if v, ok := opt.(url.Values); ok {
+		dest.RawQuery = v.Encode()
+	} else if opt != nil {
+		q, err := query.Values(opt)
+		if err != nil {
+			return nil, fmt.Errorf("error creating query string for request: %v", err)
+		}
+		dest.RawQuery = q.Encode()
+	}
+
+	req, err := retryHttp.NewRequest(http.MethodGet, dest.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %v", err)
+	}
+	req = req.WithContext(ctx)
+
+	token, err := c.token.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent())
+	req.SetBasicAuth(token, "X")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		if isDomainError(err) {
+			return res, &domainError{Host: c.baseUrl.Host, err: err}
+		}
+		return res, fmt.Errorf("error sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 204 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		return res, newApiError(res, path, body)
+	}
+
+	if err = json.NewDecoder(res.Body).Decode(out); err != nil {
+		return res, fmt.Errorf("unable to decode response: %v", err)
+	}
+
+	return res, nil
+}
+
+// checkRetry retries in line with the default policy of retryablehttp, except for a host which can't be resolved.
+func checkRetry(ctx context.Context, res *http.Response, err error) (bool, error) {
+	if err != nil && isUnknownHostError(err) {
+		return false, nil
+	}
+
+	return retryHttp.DefaultRetryPolicy(ctx, res, err)
+}
+
+// backoff honours the Retry-After header of throttled responses, otherwise it backs off exponentially
+// (with jitter) from min, never waiting longer than max.
+func backoff(min time.Duration, max time.Duration, attemptNum int, res *http.Response) time.Duration {
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := max
+	if attemptNum < 32 {
+		if exp := min * time.Duration(1<<attemptNum); exp > 0 && exp < max {
+			wait = exp
+		}
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses the value of a Retry-After header, which can be either a number of seconds or a http date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
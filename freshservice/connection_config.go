@@ -6,17 +6,98 @@ import (
 )
 
 type PluginConfig struct {
-	Domain *string `cty:"domain"`
-	Token  *string `cty:"token"`
+	Domain             *string `cty:"domain"`
+	BaseUrl            *string `cty:"base_url"`
+	Token              *string `cty:"token"`
+	TokenFile          *string `cty:"token_file"`
+	TokenCommand       *string `cty:"token_command"`
+	MaxRetryAttempts   *int    `cty:"max_retry_attempts"`
+	MaxRetryWait       *int    `cty:"max_retry_wait"`
+	RequestsPerMinute  *int    `cty:"requests_per_minute"`
+	MaxConcurrency     *int    `cty:"max_concurrency"`
+	PagePrefetch       *int    `cty:"page_prefetch"`
+	WorkspaceIds       []int   `cty:"workspace_ids"`
+	CustomFieldColumns *bool   `cty:"custom_field_columns"`
+	AssetTypeTables    *bool   `cty:"asset_type_tables"`
+	CustomObjectTables *bool   `cty:"custom_object_tables"`
+	HttpProxy          *string `cty:"http_proxy"`
+	RequestTimeout     *int    `cty:"request_timeout"`
+	CaBundleFile       *string `cty:"ca_bundle_file"`
+	InsecureSkipVerify *bool   `cty:"insecure_skip_verify"`
+	DebugHttp          *bool   `cty:"debug_http"`
+	DebugHttpBodies    *bool   `cty:"debug_http_bodies"`
+	CassetteMode       *string `cty:"cassette_mode"`
+	CassetteFile       *string `cty:"cassette_file"`
 }
 
 var ConfigSchema = map[string]*schema.Attribute{
 	"domain": {
 		Type: schema.TypeString,
 	},
+	"base_url": {
+		Type: schema.TypeString,
+	},
 	"token": {
 		Type: schema.TypeString,
 	},
+	"token_file": {
+		Type: schema.TypeString,
+	},
+	"token_command": {
+		Type: schema.TypeString,
+	},
+	"max_retry_attempts": {
+		Type: schema.TypeInt,
+	},
+	"max_retry_wait": {
+		Type: schema.TypeInt,
+	},
+	"requests_per_minute": {
+		Type: schema.TypeInt,
+	},
+	"max_concurrency": {
+		Type: schema.TypeInt,
+	},
+	"page_prefetch": {
+		Type: schema.TypeInt,
+	},
+	"workspace_ids": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeInt},
+	},
+	"custom_field_columns": {
+		Type: schema.TypeBool,
+	},
+	"asset_type_tables": {
+		Type: schema.TypeBool,
+	},
+	"custom_object_tables": {
+		Type: schema.TypeBool,
+	},
+	"http_proxy": {
+		Type: schema.TypeString,
+	},
+	"request_timeout": {
+		Type: schema.TypeInt,
+	},
+	"ca_bundle_file": {
+		Type: schema.TypeString,
+	},
+	"insecure_skip_verify": {
+		Type: schema.TypeBool,
+	},
+	"debug_http": {
+		Type: schema.TypeBool,
+	},
+	"debug_http_bodies": {
+		Type: schema.TypeBool,
+	},
+	"cassette_mode": {
+		Type: schema.TypeString,
+	},
+	"cassette_file": {
+		Type: schema.TypeString,
+	},
 }
 
 func ConfigInstance() interface{} {
@@ -0,0 +1,144 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenCommandTimeout is the longest token_command is allowed to run for.
+const tokenCommandTimeout = 30 * time.Second
+
+// tokenSource supplies the API token sent with every request.
+type tokenSource interface {
+	Token() (string, error)
+}
+
+// staticToken is a token set in the connection config or environment.
+type staticToken string
+
+func (t staticToken) Token() (string, error) {
+	return string(t), nil
+}
+
+// fileToken reads the token from a file, such as a mounted secret, the file is read again whenever it is modified so
+// that a rotated token is picked up without restarting Steampipe.
+type fileToken struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+func newFileToken(path string) (*fileToken, error) {
+	t := &fileToken{path: path}
+	if _, err := t.Token(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *fileToken) Token() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token from token_file '%s': %v", t.path, err)
+	}
+	if t.token != "" && info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return t.token, nil
+	}
+
+	raw, err := os.ReadFile(t.path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token from token_file '%s': %v", t.path, err)
+	}
+
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", fmt.Errorf("token_file '%s' is empty", t.path)
+	}
+
+	t.token = token
+	t.modTime = info.ModTime()
+	t.size = info.Size()
+
+	return t.token, nil
+}
+
+// commandToken runs token_command (through the shell) and uses its trimmed stdout as the token. The command is only run
+// when the client of the connection is created, so a token it returns is used until the connection config changes.
+// Stdout is never included in an error as it may hold part of the token, stderr is included to explain a failure.
+func commandToken(ctx context.Context, command string) (staticToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("token_command did not complete within %s", tokenCommandTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("token_command failed: %v: %s", err, msg)
+		}
+		return "", fmt.Errorf("token_command failed: %v", err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token_command did not write a token to stdout")
+	}
+
+	return staticToken(token), nil
+}
+
+// connectionToken returns the tokenSource configured for the connection, only one of token, token_file and
+// token_command may be set in the config. FRESHSERVICE_TOKEN is used when none of them are.
+func connectionToken(ctx context.Context, config PluginConfig) (tokenSource, error) {
+	var sources []string
+	if config.Token != nil && *config.Token != "" {
+		sources = append(sources, "token")
+	}
+	if config.TokenFile != nil && *config.TokenFile != "" {
+		sources = append(sources, "token_file")
+	}
+	if config.TokenCommand != nil && *config.TokenCommand != "" {
+		sources = append(sources, "token_command")
+	}
+
+	if len(sources) > 1 {
+		return nil, fmt.Errorf("only one of the configuration options 'token', 'token_file' or 'token_command' may be set, got '%s'", strings.Join(sources, "', '"))
+	}
+
+	switch {
+	case config.TokenFile != nil && *config.TokenFile != "":
+		return newFileToken(*config.TokenFile)
+	case config.TokenCommand != nil && *config.TokenCommand != "":
+		return commandToken(ctx, *config.TokenCommand)
+	case config.Token != nil && *config.Token != "":
+		return staticToken(*config.Token), nil
+	case os.Getenv("FRESHSERVICE_TOKEN") != "":
+		return staticToken(os.Getenv("FRESHSERVICE_TOKEN")), nil
+	default:
+		return nil, nil
+	}
+}
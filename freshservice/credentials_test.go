@@ -0,0 +1,80 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileToken(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte(testToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := startPlugin(t, fmt.Sprintf("token_file = \"%s\"", file))
+	p.mustQuery(t, "freshservice_agent", nil, 0)
+
+	// rotate the token, the file must be read again for the next request
+	p.api.Token = "rotated-token"
+	if err := os.WriteFile(file, []byte("rotated-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	p.mustQuery(t, "freshservice_ticket", nil, 0)
+}
+
+func TestCommandToken(t *testing.T) {
+	p := startPlugin(t, fmt.Sprintf("token_command = \"echo %s\"", testToken))
+
+	p.mustQuery(t, "freshservice_agent", nil, 0)
+}
+
+func TestTokenSourceErrors(t *testing.T) {
+	secret := "super-secret-value"
+	dir := t.TempDir()
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config PluginConfig
+		want   string
+	}{
+		{name: "missing file", config: PluginConfig{TokenFile: ptr(filepath.Join(dir, "missing"))}, want: "token_file"},
+		{name: "empty file", config: PluginConfig{TokenFile: ptr(empty)}, want: "token_file"},
+		{name: "failing command", config: PluginConfig{TokenCommand: ptr("echo " + secret + "; exit 3")}, want: "token_command failed"},
+		{name: "command with stderr", config: PluginConfig{TokenCommand: ptr("echo " + secret + "; echo vault is locked >&2; exit 3")}, want: "vault is locked"},
+		{name: "silent command", config: PluginConfig{TokenCommand: ptr("true")}, want: "token_command"},
+		{name: "several sources", config: PluginConfig{Token: ptr(secret), TokenFile: ptr(empty)}, want: "only one"},
+	}
+
+	for _, tt := range tests {
+		_, err := connectionToken(context.Background(), tt.config)
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected the error to mention '%s', got %v", tt.name, tt.want, err)
+		}
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("%s: the error must not contain the token, got %v", tt.name, err)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,173 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"regexp"
+	"strings"
+)
+
+// customFieldPrefix is prepended to the name of a custom field to form its column name, so that custom fields never
+// clash with the static columns of a table.
+const customFieldPrefix = "cf_"
+
+// invalidColumnChars matches the characters of a field name which may not be used in a column name.
+var invalidColumnChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// formField is a field of the ticket (or change, problem or release) form. Name is the key of the field within
+// custom_fields, unlike Label it never changes once the field is created.
+type formField struct {
+	ID           int64        `json:"id"`
+	Name         string       `json:"name"`
+	Label        string       `json:"label"`
+	Description  string       `json:"description"`
+	FieldType    string       `json:"field_type"`
+	DefaultField bool         `json:"default_field"`
+	NestedFields []formField  `json:"nested_fields"`
+	Choices      []formChoice `json:"choices"`
+}
+
+// customFieldForm is a form whose custom fields are added as columns to table, the fields are listed at path under
+// the root element.
+type customFieldForm struct {
+	table string
+	item  string
+	path  string
+	root  string
+}
+
+var customFieldForms = []customFieldForm{
+	{table: "freshservice_ticket", item: "ticket", path: "ticket_form_fields", root: "ticket_fields"},
+	{table: "freshservice_change", item: "change", path: "change_form_fields", root: "change_fields"},
+	{table: "freshservice_problem", item: "problem", path: "problem_form_fields", root: "problem_fields"},
+	{table: "freshservice_release", item: "release", path: "release_form_fields", root: "release_fields"},
+}
+
+// The items of tickets, changes, problems & releases, go-freshservice doesn't decode their custom fields (or the
+// workspace they belong to).
+
+type ticketItem struct {
+	fs.Ticket
+	WorkspaceID  *int                   `json:"workspace_id"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+type ticketItems struct {
+	Collection []ticketItem `json:"tickets"`
+}
+
+type changeItem struct {
+	fs.Change
+	WorkspaceID  *int                   `json:"workspace_id"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+type changeItems struct {
+	Collection []changeItem `json:"changes"`
+}
+
+type problemItem struct {
+	fs.Problem
+	WorkspaceID  *int                   `json:"workspace_id"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+type problemItems struct {
+	Collection []problemItem `json:"problems"`
+}
+
+type releaseItem struct {
+	fs.Release
+	WorkspaceID  *int                   `json:"workspace_id"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+type releaseItems struct {
+	Collection []releaseItem `json:"releases"`
+}
+
+// customFieldColumnTypes maps the field_type of a custom field to the type of its column, other field types (such as
+// text, dropdowns & urls) are strings.
+var customFieldColumnTypes = map[string]proto.ColumnType{
+	"custom_number":                proto.ColumnType_INT,
+	"custom_lookup_bigint":         proto.ColumnType_INT,
+	"custom_decimal":               proto.ColumnType_DOUBLE,
+	"custom_checkbox":              proto.ColumnType_BOOL,
+	"custom_date":                  proto.ColumnType_TIMESTAMP,
+	"custom_date_time":             proto.ColumnType_TIMESTAMP,
+	"custom_multi_select_dropdown": proto.ColumnType_JSON,
+}
+
+// addCustomFieldColumns adds a typed column for each custom field of the ticket, change, problem & release forms to
+// their tables. A table is still loaded, without the custom field columns, when its form can't be read (such as when
+// the module isn't enabled).
+func addCustomFieldColumns(ctx context.Context, d *plugin.TableMapData, tables map[string]*plugin.Table) {
+	client, err := connectionClient(ctx, d.Connection, d.ConnectionCache)
+	if err != nil {
+		plugin.Logger(ctx).Warn("freshservice.addCustomFieldColumns", "connection_error", err)
+		return
+	}
+
+	for _, form := range customFieldForms {
+		fields := make(map[string][]formField)
+		if _, err = client.list(ctx, form.path, nil, &fields); err != nil {
+			plugin.Logger(ctx).Warn("freshservice.addCustomFieldColumns", "query_error", fmt.Errorf("unable to obtain %s form fields: %w", form.item, err))
+			continue
+		}
+
+		table := tables[form.table]
+		table.Columns = append(table.Columns, customFieldColumns(form.item, fields[form.root])...)
+	}
+}
+
+// customFieldColumns returns a column for each custom field of a form, the column of a field is named after its Name
+// (not its Label) so that queries aren't broken by a field being relabelled.
+func customFieldColumns(item string, fields []formField) []*plugin.Column {
+	var columns []*plugin.Column
+	seen := make(map[string]bool)
+
+	var add func(f formField)
+	add = func(f formField) {
+		name := customFieldColumnName(f.Name)
+		if !f.DefaultField && name != customFieldPrefix && !seen[name] {
+			seen[name] = true
+
+			columnType, ok := customFieldColumnTypes[f.FieldType]
+			if !ok {
+				columnType = proto.ColumnType_STRING
+			}
+
+			columns = append(columns, &plugin.Column{
+				Name:        name,
+				Description: fmt.Sprintf("Custom field '%s' (%s) of the %s.", f.Label, f.Name, item),
+				Type:        columnType,
+				Transform:   transform.FromField("CustomFields." + f.Name),
+			})
+		}
+
+		for _, nested := range f.NestedFields {
+			add(nested)
+		}
+	}
+
+	for _, f := range fields {
+		add(f)
+	}
+
+	return columns
+}
+
+// customFieldColumnName returns the column name of the custom field with the given name.
+func customFieldColumnName(name string) string {
+	return customFieldPrefix + columnName(name)
+}
+
+// columnName converts name to a valid column (or table) name, such as network_device for "Network Device".
+func columnName(name string) string {
+	name = invalidColumnChars.ReplaceAllString(strings.ToLower(name), "_")
+	return strings.Trim(name, "_")
+}
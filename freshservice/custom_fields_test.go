@@ -0,0 +1,136 @@
+package freshservice
+
+import (
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"net/http"
+	"steampipe-plugin-freshservice/freshservice/internal/fakeapi"
+	"strings"
+	"testing"
+)
+
+func TestCustomFieldColumns(t *testing.T) {
+	fields := []formField{
+		{Name: "subject", Label: "Subject", FieldType: "default_subject", DefaultField: true},
+		{Name: "affected_system", Label: "Affected System", FieldType: "custom_text"},
+		{Name: "Root Cause-Code", Label: "Root Cause", FieldType: "custom_number"},
+		{Name: "due_review", Label: "Due Review", FieldType: "custom_date"},
+		{Name: "region", Label: "Region", FieldType: "nested_field", NestedFields: []formField{{Name: "country", Label: "Country"}}},
+		{Name: "affected_system", Label: "Duplicate", FieldType: "custom_text"},
+	}
+
+	want := map[string]proto.ColumnType{
+		"cf_affected_system": proto.ColumnType_STRING,
+		"cf_root_cause_code": proto.ColumnType_INT,
+		"cf_due_review":      proto.ColumnType_TIMESTAMP,
+		"cf_region":          proto.ColumnType_STRING,
+		"cf_country":         proto.ColumnType_STRING,
+	}
+
+	columns := customFieldColumns("ticket", fields)
+	if len(columns) != len(want) {
+		t.Errorf("expected %d columns, got %d", len(want), len(columns))
+	}
+	for _, c := range columns {
+		if columnType, ok := want[c.Name]; !ok || c.Type != columnType {
+			t.Errorf("unexpected column %s of type %v", c.Name, c.Type)
+		}
+	}
+
+	// relabelling a field must not change its column
+	fields[1].Label = "Impacted System"
+	if name := customFieldColumns("ticket", fields)[0].Name; name != "cf_affected_system" {
+		t.Errorf("expected a relabelled field to keep its column name, got %s", name)
+	}
+}
+
+func TestCustomFields(t *testing.T) {
+	p := startPlugin(t, "custom_field_columns = true")
+
+	rows := p.mustQuery(t, "freshservice_ticket", quals{"id": 1}, 0)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+
+	assertRow(t, rows, row{
+		"id":                 int64(1),
+		"cf_affected_system": "VPN",
+		"cf_root_cause_code": int64(12),
+		"cf_due_review":      ts("2023-03-01T00:00:00Z"),
+		"cf_vip":             true,
+		"cf_region":          "EMEA",
+		"cf_country":         "UK",
+	})
+	if cf, ok := rows[0]["custom_fields"].(map[string]interface{}); !ok || cf["affected_system"] != "VPN" {
+		t.Errorf("expected custom_fields to hold the custom field values, got %v", rows[0]["custom_fields"])
+	}
+
+	rows = p.mustQuery(t, "freshservice_ticket", quals{"id": 2}, 0)
+	if len(rows) != 1 || rows[0]["cf_affected_system"] != nil || rows[0]["cf_vip"] != false {
+		t.Errorf("expected empty custom fields to be null, got %v", rows)
+	}
+
+	assertRow(t, p.mustQuery(t, "freshservice_change", nil, 0), row{
+		"id":                     int64(1),
+		"cf_backout_plan_tested": true,
+		"cf_cab_decision":        "Approved",
+		"cf_affected_services":   []interface{}{"Email", "VPN"},
+	})
+	assertRow(t, p.mustQuery(t, "freshservice_problem", nil, 0), row{
+		"id":                     int64(1),
+		"cf_root_cause_category": "Software",
+		"custom_fields":          map[string]interface{}{"root_cause_category": "Software"},
+	})
+
+	// the release form isn't available, so releases only have the custom_fields column
+	schema, err := p.client.GetSchema(p.connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range schema.Schema["freshservice_release"].Columns {
+		if strings.HasPrefix(c.Name, customFieldPrefix) {
+			t.Errorf("expected releases to have no custom field columns, got %s", c.Name)
+		}
+	}
+	if rows = p.mustQuery(t, "freshservice_release", nil, 0); len(rows) != 2 {
+		t.Errorf("expected 2 releases, got %d", len(rows))
+	}
+}
+
+func TestCustomFieldColumnsOptional(t *testing.T) {
+	unavailable := fakeapi.New(testToken)
+	unavailable.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.HasSuffix(r.URL.Path, "/ticket_form_fields") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":"access_denied","message":"You are not authorized to perform this action."}`))
+			return true
+		}
+		return false
+	}
+
+	tests := []struct {
+		name string
+		p    *testPlugin
+	}{
+		{name: "disabled", p: startPlugin(t, "")},
+		{name: "form unavailable", p: startPluginWithApi(t, unavailable, "custom_field_columns = true")},
+	}
+
+	for _, tt := range tests {
+		schema, err := tt.p.client.GetSchema(tt.p.connection)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		for _, c := range schema.Schema["freshservice_ticket"].Columns {
+			if strings.HasPrefix(c.Name, customFieldPrefix) {
+				t.Errorf("%s: expected no custom field columns, got %s", tt.name, c.Name)
+			}
+		}
+
+		// the tickets can still be queried
+		if rows := tt.p.mustQuery(t, "freshservice_ticket", nil, 0); len(rows) != 3 {
+			t.Errorf("%s: expected 3 rows, got %d", tt.name, len(rows))
+		}
+	}
+}
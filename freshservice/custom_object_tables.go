@@ -0,0 +1,344 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// customObjectTablePrefix is prepended to the title of a custom object to form the name of its table.
+const customObjectTablePrefix = "freshservice_custom_object_"
+
+// customObjectSystemFieldPrefix is the prefix of the fields FreshService maintains on every record, such as
+// bo_display_id, which are the fixed columns of every custom object table.
+const customObjectSystemFieldPrefix = "bo_"
+
+// customObject is a custom object of the instance, Fields is only populated when a single object is obtained.
+type customObject struct {
+	ID          int64               `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Fields      []customObjectField `json:"fields"`
+}
+
+type customObjects struct {
+	Collection []customObject `json:"custom_objects"`
+}
+
+// customObjectField is a field of a custom object, Name is the key of the field within a record.
+type customObjectField struct {
+	Name      string `json:"name"`
+	Label     string `json:"label"`
+	FieldType string `json:"type"`
+}
+
+// customObjectRecords is a page of the records of a custom object, NextPageLink is empty on the last page.
+type customObjectRecords struct {
+	Records      []map[string]interface{} `json:"records"`
+	NextPageLink string                   `json:"next_page_link"`
+}
+
+// customObjectFieldColumnTypes maps the type of a custom object field to the type of its column, other field types
+// (such as text & dropdowns) are strings.
+var customObjectFieldColumnTypes = map[string]proto.ColumnType{
+	"NUMBER":       proto.ColumnType_INT,
+	"DECIMAL":      proto.ColumnType_DOUBLE,
+	"CHECKBOX":     proto.ColumnType_BOOL,
+	"DATE":         proto.ColumnType_TIMESTAMP,
+	"RELATIONSHIP": proto.ColumnType_JSON,
+}
+
+// customObjectFilterTypes are the types of field which the records API can filter on.
+var customObjectFilterTypes = map[string]bool{
+	"TEXT":     true,
+	"DROPDOWN": true,
+	"NUMBER":   true,
+	"CHECKBOX": true,
+}
+
+// customObjectFilter is a column of a custom object table whose equality quals are passed to the records API.
+type customObjectFilter struct {
+	column string
+	field  string
+}
+
+// addCustomObjectTables adds a table for every custom object (such as freshservice_custom_object_application_owners)
+// with a column for each field of the object. No tables are added when the custom objects can't be read, the table of
+// an object is added without its field columns when its fields can't be read.
+func addCustomObjectTables(ctx context.Context, d *plugin.TableMapData, tables map[string]*plugin.Table) {
+	client, err := connectionClient(ctx, d.Connection, d.ConnectionCache)
+	if err != nil {
+		plugin.Logger(ctx).Warn("freshservice.addCustomObjectTables", "connection_error", err)
+		return
+	}
+
+	objects, err := listAll(ctx, client, "objects", func(objects *customObjects) []customObject {
+		return objects.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Warn("freshservice.addCustomObjectTables", "query_error", fmt.Errorf("unable to obtain custom objects: %w", err))
+		return
+	}
+
+	for _, object := range objects {
+		detail := new(customObject)
+		if err = client.get(ctx, fmt.Sprintf("objects/%d", object.ID), "custom_object", detail); err != nil {
+			plugin.Logger(ctx).Warn("freshservice.addCustomObjectTables", "query_error", fmt.Errorf("unable to obtain fields of custom object %d: %w", object.ID, err))
+		}
+		object.Fields = detail.Fields
+
+		name := customObjectTablePrefix + columnName(object.Title)
+		if _, exists := tables[name]; exists || name == customObjectTablePrefix {
+			name = fmt.Sprintf("%s_%d", strings.TrimSuffix(name, "_"), object.ID)
+		}
+
+		tables[name] = tableCustomObject(name, object)
+	}
+}
+
+// tableCustomObject is a table of the records of a custom object, with a column for each of its fields. Equality quals
+// (and in lists) on text, dropdown, number & checkbox fields are passed to FreshService.
+func tableCustomObject(name string, object customObject) *plugin.Table {
+	fieldColumns, filters := customObjectFieldColumns(object.Fields)
+
+	keyColumns := make([]*plugin.KeyColumn, 0, len(filters))
+	for _, f := range filters {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{Name: f.column, Require: plugin.Optional})
+	}
+
+	return withDomainColumn(&plugin.Table{
+		Name:        name,
+		Description: fmt.Sprintf("Obtain the records of the %s custom object (ID %d). %s", object.Title, object.ID, object.Description),
+		List: &plugin.ListConfig{
+			Hydrate:    listCustomObjectRecords(object.ID, filters),
+			KeyColumns: keyColumns,
+		},
+		Columns: append(customObjectColumns(), fieldColumns...),
+	})
+}
+
+// customObjectColumns are the columns of every custom object table, populated from the fields FreshService maintains
+// on each record.
+func customObjectColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "id",
+			Description: "ID of the record.",
+			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("bo_display_id"),
+		},
+		{
+			Name:        "created_by",
+			Description: "ID of the user who created the record.",
+			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("bo_created_by"),
+		},
+		{
+			Name:        "updated_by",
+			Description: "ID of the user who last updated the record.",
+			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("bo_updated_by"),
+		},
+		{
+			Name:        "created_at",
+			Description: "Timestamp when the record was created.",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Transform:   transform.FromField("bo_created_at"),
+		},
+		{
+			Name:        "updated_at",
+			Description: "Timestamp when the record was last updated.",
+			Type:        proto.ColumnType_TIMESTAMP,
+			Transform:   transform.FromField("bo_updated_at"),
+		},
+		{
+			Name:        "record",
+			Description: "Every field of the record, keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromValue(),
+		},
+	}
+}
+
+// customObjectFieldColumns returns a column for each of fields, named after the name of the field, along with the
+// columns whose quals can be passed to the records API. A field whose name clashes with a fixed column (such as id) is
+// only available from the record column.
+func customObjectFieldColumns(fields []customObjectField) ([]*plugin.Column, []customObjectFilter) {
+	seen := make(map[string]bool)
+	for _, c := range customObjectColumns() {
+		seen[c.Name] = true
+	}
+	seen[domainColumn().Name] = true
+
+	var columns []*plugin.Column
+	var filters []customObjectFilter
+	for _, f := range fields {
+		name := columnName(f.Name)
+		if strings.HasPrefix(f.Name, customObjectSystemFieldPrefix) || name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		columnType, ok := customObjectFieldColumnTypes[f.FieldType]
+		if !ok {
+			columnType = proto.ColumnType_STRING
+		}
+
+		columns = append(columns, &plugin.Column{
+			Name:        name,
+			Description: fmt.Sprintf("%s (%s).", f.Label, f.Name),
+			Type:        columnType,
+			Transform:   transform.FromField(f.Name),
+		})
+
+		if customObjectFilterTypes[f.FieldType] {
+			filters = append(filters, customObjectFilter{column: name, field: f.Name})
+		}
+	}
+
+	return columns, filters
+}
+
+// listCustomObjectRecords returns the List hydrate of the table of a custom object.
+func listCustomObjectRecords(objectID int64, filters []customObjectFilter) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		client, err := connect(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listCustomObjectRecords", "connection_error", err)
+			return nil, fmt.Errorf("unable to create FreshService client: %v", err)
+		}
+
+		queries, err := customObjectQueries(d, filters)
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listCustomObjectRecords", "query_error", err)
+			return nil, err
+		}
+
+		for _, query := range queries {
+			if err = streamCustomObjectRecords(ctx, d, client, objectID, query); err != nil || d.RowsRemaining(ctx) <= 0 {
+				break
+			}
+		}
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listCustomObjectRecords", "query_error", err)
+			return nil, fmt.Errorf("unable to obtain records of custom object %d: %w", objectID, err)
+		}
+
+		return nil, nil
+	}
+}
+
+// streamCustomObjectRecords streams the records of a custom object matching query, the records API is paginated by
+// following the next_page_link of each page rather than by page number.
+func streamCustomObjectRecords(ctx context.Context, d *plugin.QueryData, client *apiClient, objectID int64, query string) error {
+	remaining := d.RowsRemaining(ctx)
+	if remaining <= 0 {
+		return nil
+	}
+
+	q := url.Values{"page_size": {strconv.Itoa(maxPerPage)}}
+	if remaining < maxPerPage {
+		q.Set("page_size", strconv.FormatInt(remaining, 10))
+	}
+	if query != "" {
+		q.Set("query", query)
+	}
+
+	path := fmt.Sprintf("objects/%d/records", objectID)
+	for {
+		records := new(customObjectRecords)
+		if _, err := client.list(ctx, path, q, records); err != nil {
+			return err
+		}
+
+		for _, r := range records.Records {
+			d.StreamListItem(ctx, r)
+
+			if d.RowsRemaining(ctx) <= 0 {
+				return nil
+			}
+		}
+
+		if records.NextPageLink == "" || len(records.Records) == 0 {
+			return nil
+		}
+
+		next, err := url.Parse(records.NextPageLink)
+		if err != nil {
+			return fmt.Errorf("unable to parse next page link %s: %v", records.NextPageLink, err)
+		}
+
+		// the link only holds the token of the next page, the query & page size of the first page still apply
+		nextQuery := next.Query()
+		for _, key := range []string{"query", "page_size"} {
+			if !nextQuery.Has(key) && q.Has(key) {
+				nextQuery.Set(key, q.Get(key))
+			}
+		}
+		q = nextQuery
+	}
+}
+
+// customObjectValueEscaper escapes a string value of a records API query, which is enclosed in single quotes.
+var customObjectValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// customObjectQueries returns the queries of the records API for the equality quals of filters, such as
+// "status : 'Active' AND tier : 1", whose records are listed in turn. Each combination of the values of lists (from in
+// or = any) is a separate query, as the records API can't express alternatives. Every qual of a key column must be
+// sent to FreshService, as Steampipe may have passed the limit of the query on, so a qual which can't be expressed is
+// an error.
+func customObjectQueries(d *plugin.QueryData, filters []customObjectFilter) ([]string, error) {
+	queries := []string{""}
+	for _, f := range filters {
+		q := d.EqualsQuals[f.column]
+		if q == nil {
+			continue
+		}
+
+		values := []*proto.QualValue{q}
+		if list := q.GetListValue(); list != nil {
+			values = list.Values
+		}
+
+		conditions := make([]string, 0, len(values))
+		for _, v := range values {
+			condition, err := customObjectCondition(f, v)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+
+		combined := make([]string, 0, len(queries)*len(conditions))
+		for _, query := range queries {
+			for _, condition := range conditions {
+				if query != "" {
+					condition = query + " AND " + condition
+				}
+				combined = append(combined, condition)
+			}
+		}
+		queries = combined
+	}
+
+	return queries, nil
+}
+
+// customObjectCondition returns the condition of the records API comparing the field of f to a single value.
+func customObjectCondition(f customObjectFilter, value *proto.QualValue) (string, error) {
+	switch v := value.Value.(type) {
+	case *proto.QualValue_StringValue:
+		return fmt.Sprintf("%s : '%s'", f.field, customObjectValueEscaper.Replace(v.StringValue)), nil
+	case *proto.QualValue_Int64Value:
+		return fmt.Sprintf("%s : %d", f.field, v.Int64Value), nil
+	case *proto.QualValue_BoolValue:
+		return fmt.Sprintf("%s : %t", f.field, v.BoolValue), nil
+	}
+
+	return "", fmt.Errorf("unable to filter custom object records on %s by %v", f.column, value)
+}
@@ -0,0 +1,146 @@
+package freshservice
+
+import (
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"strings"
+	"testing"
+)
+
+func TestCustomObjectFieldColumns(t *testing.T) {
+	fields := []customObjectField{
+		{Name: "bo_display_id", Label: "Record ID", FieldType: "NUMBER"},
+		{Name: "application", Label: "Application", FieldType: "TEXT"},
+		{Name: "Annual Cost", Label: "Annual Cost", FieldType: "DECIMAL"},
+		{Name: "owner", Label: "Owner", FieldType: "RELATIONSHIP"},
+		{Name: "record", Label: "Record", FieldType: "TEXT"},
+		{Name: "ID", Label: "ID", FieldType: "NUMBER"},
+		{Name: "application", Label: "Duplicate", FieldType: "TEXT"},
+	}
+
+	want := map[string]proto.ColumnType{
+		"application": proto.ColumnType_STRING,
+		"annual_cost": proto.ColumnType_DOUBLE,
+		"owner":       proto.ColumnType_JSON,
+	}
+
+	columns, filters := customObjectFieldColumns(fields)
+	if len(columns) != len(want) {
+		t.Errorf("expected %d columns, got %d", len(want), len(columns))
+	}
+	for _, c := range columns {
+		if columnType, ok := want[c.Name]; !ok || c.Type != columnType {
+			t.Errorf("unexpected column %s of type %v", c.Name, c.Type)
+		}
+	}
+
+	if len(filters) != 1 || filters[0].column != "application" {
+		t.Errorf("expected only application to be filterable, got %v", filters)
+	}
+}
+
+func TestCustomObjectTables(t *testing.T) {
+	p := startPlugin(t, "custom_object_tables = true")
+	// a small page size checks the records are paginated by next_page_link
+	p.api.MaxPerPage = 2
+
+	schema, err := p.client.GetSchema(p.connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"freshservice_custom_object_application_owners", "freshservice_custom_object_on_call_rotation"} {
+		if _, ok := schema.Schema[table]; !ok {
+			t.Errorf("expected table %s", table)
+		}
+	}
+
+	rows := p.mustQuery(t, "freshservice_custom_object_application_owners", nil, 0)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(rows))
+	}
+	assertRow(t, rows, row{
+		"id":          int64(1),
+		"created_at":  ts("2023-01-02T03:04:05Z"),
+		"application": "Payroll",
+		"owner_email": "jane.doe@example.com",
+		"tier":        int64(1),
+		"status":      "Active",
+		"critical":    true,
+		"annual_cost": 12000.5,
+		"review_date": ts("2024-06-30T00:00:00Z"),
+		"notes":       "Owned by finance.",
+	})
+
+	tests := []struct {
+		quals   quals
+		limit   int64
+		query   string
+		queries int
+		rows    int
+	}{
+		{quals: quals{"status": "Active"}, query: "status : 'Active'", queries: 1, rows: 2},
+		{quals: quals{"owner_email": "jane.doe@example.com", "tier": 3}, query: "owner_email : 'jane.doe@example.com' AND tier : 3", queries: 1, rows: 1},
+		{quals: quals{"critical": true}, query: "critical : true", queries: 1, rows: 1},
+		// Steampipe passes the limit on when every qual is of a key column, so quotes are escaped rather than skipped
+		{quals: quals{"owner_email": "o'brien@example.com"}, limit: 1, query: `owner_email : 'o\'brien@example.com'`, queries: 1, rows: 0},
+		// each combination of the values of several in lists is listed in turn
+		{quals: quals{"status": []string{"Active", "Retired"}, "tier": []int{1, 3}}, limit: 5, query: "status : 'Active' AND tier : 1", queries: 4, rows: 2},
+		// paragraphs can't be filtered on by the records API, so every record is listed (and filtered by Steampipe)
+		{quals: quals{"notes": "Owned by finance."}, query: "", queries: 1, rows: 3},
+	}
+
+	// a filtered result longer than one page checks the query is sent with every page
+	p.api.MaxPerPage = 1
+	for _, tt := range tests {
+		p.api.Reset()
+
+		if rows = p.mustQuery(t, "freshservice_custom_object_application_owners", tt.quals, tt.limit); len(rows) != tt.rows {
+			t.Errorf("%v: expected %d rows, got %d", tt.quals, tt.rows, len(rows))
+		}
+
+		queries := make(map[string]bool)
+		for _, r := range p.api.RequestsTo("objects/1/records") {
+			queries[r.Query().Get("query")] = true
+		}
+		if len(queries) != tt.queries {
+			t.Errorf("%v: expected %d queries, got %v", tt.quals, tt.queries, queries)
+		}
+
+		found := false
+		for q := range queries {
+			found = found || sameConditions(q, tt.query)
+		}
+		if !found {
+			t.Errorf("%v: expected query %q, got %v", tt.quals, tt.query, queries)
+		}
+	}
+}
+
+func TestCustomObjectTablesOptional(t *testing.T) {
+	p := startPlugin(t, "")
+
+	schema, err := p.client.GetSchema(p.connection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range schema.Schema {
+		if strings.HasPrefix(name, customObjectTablePrefix) {
+			t.Errorf("expected no custom object tables, got %s", name)
+		}
+	}
+}
+
+// sameConditions reports whether the records API queries a & b hold the same conditions, in any order.
+func sameConditions(a string, b string) bool {
+	conditions := make(map[string]bool)
+	for _, c := range strings.Split(a, " AND ") {
+		conditions[c] = true
+	}
+	for _, c := range strings.Split(b, " AND ") {
+		if !conditions[c] {
+			return false
+		}
+		delete(conditions, c)
+	}
+
+	return len(conditions) == 0
+}
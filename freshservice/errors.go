@@ -0,0 +1,223 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The classes of API error a user can act on, an apiError wraps one of these so that it can be tested with errors.Is.
+var (
+	errUnauthorized    = errors.New("the API key was rejected")
+	errForbidden       = errors.New("access denied")
+	errFeatureDisabled = errors.New("feature not enabled")
+	errNotFound        = errors.New("not found")
+	errNoAccount       = errors.New("no FreshService account found")
+	errRateLimited     = errors.New("rate limit exceeded")
+)
+
+// featureDisabledCodes are the error codes FreshService responds with when a module is not enabled for the account.
+var featureDisabledCodes = map[string]bool{
+	"require_feature":       true,
+	"feature_not_enabled":   true,
+	"feature_not_supported": true,
+}
+
+// resourceNames describes the resource of an API path (by its first segment) where this isn't the segment itself.
+var resourceNames = map[string]string{
+	"applications":    "software",
+	"roles":           "agent roles",
+	"service_catalog": "service catalog items",
+	"sla_policies":    "SLA policies",
+	"solutions":       "solutions",
+}
+
+// apiError is returned when the FreshService API responds with a non-success status, the message of the error
+// includes a hint on how the user can resolve it.
+type apiError struct {
+	StatusCode int
+	Status     string
+	Path       string
+	Code       string
+	Message    string
+	kind       error
+}
+
+// newApiError classifies the non-success response res to a request for path, body is the (possibly empty) body of
+// the response.
+func newApiError(res *http.Response, path string, body []byte) *apiError {
+	e := &apiError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Path:       path,
+	}
+
+	// errors are described as {"code": "...", "message": "..."} or {"description": "...", "errors": [...]}
+	var detail struct {
+		Code        string `json:"code"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	}
+	isJSON := json.Unmarshal(body, &detail) == nil
+	e.Code = detail.Code
+	e.Message = detail.Message
+	if e.Message == "" {
+		e.Message = detail.Description
+	}
+
+	switch {
+	case featureDisabledCodes[e.Code]:
+		e.kind = errFeatureDisabled
+	case res.StatusCode == http.StatusUnauthorized:
+		e.kind = errUnauthorized
+	case res.StatusCode == http.StatusForbidden:
+		e.kind = errForbidden
+	case res.StatusCode == http.StatusTooManyRequests:
+		e.kind = errRateLimited
+	case res.StatusCode == http.StatusNotFound && !isJSON:
+		// the API always describes a missing record in JSON, anything else is a page served for an unknown domain
+		e.kind = errNoAccount
+	case res.StatusCode == http.StatusNotFound:
+		e.kind = errNotFound
+	}
+
+	return e
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("FreshService returned %s for '%s'", e.Status, e.Path)
+	if e.Code != "" && e.Message != "" {
+		msg += fmt.Sprintf(" (%s: %s)", e.Code, e.Message)
+	} else if e.Message != "" {
+		msg += fmt.Sprintf(" (%s)", e.Message)
+	}
+
+	if hint := e.hint(); hint != "" {
+		msg += ", " + hint
+	}
+
+	return msg
+}
+
+func (e *apiError) Unwrap() error {
+	return e.kind
+}
+
+// hint tells the user how to resolve the error.
+func (e *apiError) hint() string {
+	resource := resourceName(e.Path)
+
+	switch e.kind {
+	case errUnauthorized:
+		return "check the 'token' is a valid API key for this FreshService domain and belongs to an active agent"
+	case errForbidden:
+		return fmt.Sprintf("the API key's agent lacks the 'view %s' scope, grant the agent a role with access to %s", resource, resource)
+	case errFeatureDisabled:
+		return fmt.Sprintf("the %s module is not enabled on this FreshService account, it may require a different plan or be switched on by an admin", resource)
+	case errNotFound:
+		return "the record does not exist, if every table returns this check the 'domain' or 'base_url' is correct"
+	case errNoAccount:
+		return "no FreshService account was found, check the 'domain' or 'base_url' is correct"
+	case errRateLimited:
+		return "the API rate limit of the account was exceeded, lower 'requests_per_minute' or 'max_concurrency' (or raise 'max_retry_attempts')"
+	default:
+		return ""
+	}
+}
+
+// resourceName describes the resource at an API path, such as "assets" for "assets/1001/components".
+func resourceName(path string) string {
+	segment := strings.SplitN(strings.Trim(path, "/"), "/", 2)[0]
+	if name, ok := resourceNames[segment]; ok {
+		return name
+	}
+
+	return strings.ReplaceAll(segment, "_", " ")
+}
+
+// domainError is returned when the host of the API can not be resolved.
+type domainError struct {
+	Host string
+	err  error
+}
+
+func (e *domainError) Error() string {
+	return fmt.Sprintf("unable to resolve FreshService host '%s', check the 'domain' or 'base_url' is correct: %v", e.Host, e.err)
+}
+
+func (e *domainError) Unwrap() error {
+	return e.err
+}
+
+// isDomainError reports whether err was caused by a failure to resolve the host of the API.
+func isDomainError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// isUnknownHostError reports whether err was caused by the host of the API not existing, which retrying won't fix.
+func isUnknownHostError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isNotFoundError reports whether err was caused by the FreshService API responding that a record does not exist.
+func isNotFoundError(err error) bool {
+	return errors.Is(err, errNotFound)
+}
+
+// isUnavailableError reports whether err was caused by FreshService refusing access to (or not having) what was
+// requested, which unlike a rate limited or failed request won't change by retrying.
+func isUnavailableError(err error) bool {
+	return errors.Is(err, errForbidden) || errors.Is(err, errFeatureDisabled) || errors.Is(err, errNotFound)
+}
+
+// shouldIgnoreNotFound is the default ignore config of the plugin, a record which does not exist (or a parent whose
+// children are listed) is returned as no row rather than failing the query. Only a 404 for a path holding a qualified
+// id is ignored, any other means the endpoint itself is missing (such as for a wrong domain, or a filtered list the
+// account can't use) so is still reported. Tables which need different handling can set their own IgnoreConfig on the
+// Get or List config.
+func shouldIgnoreNotFound(_ context.Context, d *plugin.QueryData, _ *plugin.HydrateData, err error) bool {
+	var apiErr *apiError
+	if !isNotFoundError(err) || !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, segment := range strings.Split(apiErr.Path, "/") {
+		for _, q := range d.EqualsQuals {
+			if qualHasValue(q, segment) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// qualHasValue reports whether the int or string value of q (or of any value in its list) is segment.
+func qualHasValue(q *proto.QualValue, segment string) bool {
+	if list := q.GetListValue(); list != nil {
+		for _, v := range list.Values {
+			if qualHasValue(v, segment) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch v := q.GetValue().(type) {
+	case *proto.QualValue_Int64Value:
+		return segment == strconv.FormatInt(v.Int64Value, 10)
+	case *proto.QualValue_StringValue:
+		return segment != "" && segment == v.StringValue
+	}
+
+	return false
+}
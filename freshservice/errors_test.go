@@ -0,0 +1,139 @@
+package freshservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestApiErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		status  int
+		header  http.Header
+		body    string
+		token   string
+		kind    error
+		message string
+	}{
+		{
+			name:    "invalid token",
+			table:   "freshservice_ticket",
+			token:   "another-token",
+			kind:    errUnauthorized,
+			message: "check the 'token' is a valid API key",
+		},
+		{
+			name:    "missing scope",
+			table:   "freshservice_asset",
+			status:  http.StatusForbidden,
+			body:    `{"code":"access_denied","message":"You are not authorized to perform this action."}`,
+			kind:    errForbidden,
+			message: "the API key's agent lacks the 'view assets' scope",
+		},
+		{
+			name:    "feature disabled",
+			table:   "freshservice_purchase_order",
+			status:  http.StatusForbidden,
+			body:    `{"code":"require_feature","message":"The requested feature is not enabled for your account."}`,
+			kind:    errFeatureDisabled,
+			message: "the purchase orders module is not enabled",
+		},
+		{
+			name:    "feature disabled software",
+			table:   "freshservice_software",
+			status:  http.StatusForbidden,
+			body:    `{"code":"require_feature","message":"The requested feature is not enabled for your account."}`,
+			kind:    errFeatureDisabled,
+			message: "the software module is not enabled",
+		},
+		{
+			name:    "unknown account",
+			table:   "freshservice_agent",
+			status:  http.StatusNotFound,
+			header:  http.Header{"Content-Type": {"text/html"}},
+			body:    "<html><body>There is no helpdesk here!</body></html>",
+			kind:    errNoAccount,
+			message: "no FreshService account was found, check the 'domain' or 'base_url'",
+		},
+		{
+			name:    "missing endpoint",
+			table:   "freshservice_agent",
+			status:  http.StatusNotFound,
+			body:    `{"code":"not_found","message":"The requested resource does not exist."}`,
+			kind:    errNotFound,
+			message: "check the 'domain' or 'base_url'",
+		},
+		{
+			name:    "throttled",
+			table:   "freshservice_ticket",
+			status:  http.StatusTooManyRequests,
+			header:  http.Header{"Retry-After": {"0"}},
+			body:    `{"code":"too_many_requests","message":"You have exceeded the limit of requests per minute."}`,
+			kind:    errRateLimited,
+			message: "lower 'requests_per_minute'",
+		},
+	}
+
+	for _, tt := range tests {
+		p := startPlugin(t, "max_retry_attempts = 1")
+		if tt.token != "" {
+			p.api.Token = tt.token
+		}
+		if tt.status != 0 {
+			p.api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				if w.Header().Get("Content-Type") == "" {
+					w.Header().Set("Content-Type", "application/json")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+				return true
+			}
+		}
+
+		_, err := p.query(t, tt.table, nil, 0)
+		if err == nil {
+			t.Errorf("%s: expected the query to fail", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("%s: expected the error to contain \"%s\", got %v", tt.name, tt.message, err)
+		}
+
+		// the error is only available as text once returned by the plugin, so classify the response directly too
+		res := &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Header: tt.header}
+		if tt.token != "" {
+			res.StatusCode = http.StatusUnauthorized
+		}
+		if kind := newApiError(res, "", []byte(tt.body)).kind; !errors.Is(kind, tt.kind) {
+			t.Errorf("%s: expected the response to be classified as '%v', got '%v'", tt.name, tt.kind, kind)
+		}
+	}
+}
+
+func TestDomainError(t *testing.T) {
+	baseUrl, _ := url.Parse("http://no-such-account.invalid/api/v2/")
+	config := PluginConfig{MaxRetryAttempts: ptr(1)}
+
+	client, err := newApiClient(context.Background(), baseUrl, staticToken(testToken), config, connectionRateLimiter(t.Name(), config), connectionUsage(t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.get(context.Background(), "agents/1", "agent", new(interface{}))
+
+	var domainErr *domainError
+	if !errors.As(err, &domainErr) {
+		t.Fatalf("expected a domain error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "check the 'domain' or 'base_url'") {
+		t.Errorf("expected the error to suggest checking the domain, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"reflect"
+)
+
+// formChoice is a choice of a dropdown field of a form, such as the "Awaiting Vendor" (6) status of a ticket.
+type formChoice struct {
+	ID    int    `json:"id"`
+	Value string `json:"value"`
+}
+
+// formLabels holds the label of each choice of the dropdown fields of a form, keyed by field name & choice id.
+type formLabels map[string]map[int]string
+
+// formLabelField is a field whose value is labelled by a *_desc column, Defaults holds the labels of a FreshService
+// instance which hasn't customised the field and are used when the form of the instance can't be read.
+type formLabelField struct {
+	Name     string
+	Field    string
+	Defaults map[int]string
+}
+
+// labelledItem is the item of a row along with the labels of the form of its table.
+type labelledItem struct {
+	Item   interface{}
+	Labels formLabels
+}
+
+// getFormLabels hydrates the *_desc columns of the tables with a form (tickets, changes, problems & releases).
+func getFormLabels(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	labels, err := loadFormLabels(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &labelledItem{Item: h.Item, Labels: labels.(formLabels)}, nil
+}
+
+// formLabelsHydrateConfig declares getFormLabels on the tables whose *_desc columns it hydrates, the SDK only
+// initialises the retry & ignore configs of declared hydrate functions and can't handle an error returned by any other.
+func formLabelsHydrateConfig() []plugin.HydrateConfig {
+	return []plugin.HydrateConfig{{Func: getFormLabels}}
+}
+
+// loadFormLabels reads the labels of the form of a table once per connection, a failed read isn't cached.
+var loadFormLabels = plugin.HydrateFunc(listFormLabels).Memoize(func(o *plugin.MemoizeConfiguration) {
+	o.GetCacheKeyFunc = formLabelsCacheKey
+})
+
+func formLabelsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return "freshservice-form-labels-" + d.Table.Name, nil
+}
+
+// listFormLabels reads the labels of the choices of the form of the table being queried. No labels are returned when
+// access to the form is denied (or it doesn't exist), so that the default labels are used rather than failing the
+// query. Other failures (such as rate limits & timeouts) are returned, so that the form is read again by the next
+// query.
+func listFormLabels(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	labels := make(formLabels)
+
+	var form *customFieldForm
+	for i := range customFieldForms {
+		if customFieldForms[i].table == d.Table.Name {
+			form = &customFieldForms[i]
+		}
+	}
+	if form == nil {
+		return labels, nil
+	}
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(d.Table.Name+".listFormLabels", "connection_error", err)
+		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
+	}
+
+	fields := make(map[string][]formField)
+	if _, err = client.list(ctx, form.path, nil, &fields); err != nil {
+		err = fmt.Errorf("unable to obtain %s form fields: %w", form.item, err)
+		if !isUnavailableError(err) {
+			plugin.Logger(ctx).Error(d.Table.Name+".listFormLabels", "query_error", err)
+			return nil, err
+		}
+
+		plugin.Logger(ctx).Warn(d.Table.Name+".listFormLabels", "query_error", err)
+		return labels, nil
+	}
+
+	for _, f := range fields[form.root] {
+		if len(f.Choices) == 0 {
+			continue
+		}
+		labels[f.Name] = make(map[int]string, len(f.Choices))
+		for _, c := range f.Choices {
+			labels[f.Name][c.ID] = c.Value
+		}
+	}
+
+	return labels, nil
+}
+
+// formLabel transforms a labelledItem into the label of the value of the formLabelField given as the param, the
+// default labels are used when the form doesn't hold the choices of the field.
+func formLabel(_ context.Context, input *transform.TransformData) (interface{}, error) {
+	item, ok := input.HydrateItem.(*labelledItem)
+	if !ok {
+		return "Unknown", nil
+	}
+	field := input.Param.(formLabelField)
+
+	value, ok := choiceID(helpers.GetNestedFieldValueFromInterface(item.Item, field.Field))
+	if !ok {
+		return "Unknown", nil
+	}
+
+	labels, ok := item.Labels[field.Name]
+	if !ok {
+		labels = field.Defaults
+	}
+	if label, ok := labels[value]; ok {
+		return label, nil
+	}
+
+	return "Unknown", nil
+}
+
+// choiceID returns the value of an integer field (or pointer to one), which is false when the field is unset.
+func choiceID(value interface{}, ok bool) (int, bool) {
+	if !ok || value == nil {
+		return 0, false
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,66 @@
+package freshservice
+
+import (
+	"net/http"
+	"steampipe-plugin-freshservice/freshservice/internal/fakeapi"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFormLabels(t *testing.T) {
+	p := startPlugin(t, "")
+
+	rows := p.mustQuery(t, "freshservice_ticket", nil, 0)
+	// the status & priority are labelled from the ticket form, the form doesn't customise the source or urgency
+	assertRow(t, rows, row{"id": int64(2), "status_desc": "Awaiting Vendor", "priority_desc": "P3", "source_desc": "Email", "urgency_desc": "Medium"})
+	assertRow(t, rows, row{"id": int64(3), "status_desc": "Closed", "priority_desc": "P1", "source_desc": "Phone", "impact_desc": "Medium"})
+
+	// the form is only read once per connection
+	p.mustQuery(t, "freshservice_ticket", quals{"id": 2}, 0)
+	if requests := p.api.RequestsTo("ticket_form_fields"); len(requests) != 1 {
+		t.Errorf("expected the ticket form to be read once, got %d requests", len(requests))
+	}
+
+	// the release form isn't available, so the default labels are used
+	assertRow(t, p.mustQuery(t, "freshservice_release", nil, 0), row{"id": int64(1), "release_type_desc": "Standard"})
+}
+
+func TestFormLabelsDefault(t *testing.T) {
+	api := fakeapi.New(testToken)
+	api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.HasSuffix(r.URL.Path, "/ticket_form_fields") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":"access_denied","message":"You are not authorized to perform this action."}`))
+			return true
+		}
+		return false
+	}
+	p := startPluginWithApi(t, api, "")
+
+	rows := p.mustQuery(t, "freshservice_ticket", nil, 0)
+	assertRow(t, rows, row{"id": int64(2), "status_desc": "Unknown", "priority_desc": "Medium"})
+	assertRow(t, rows, row{"id": int64(3), "status_desc": "Closed", "priority_desc": "Urgent"})
+}
+
+func TestFormLabelsTransientError(t *testing.T) {
+	var failures atomic.Int32
+	failures.Store(1)
+
+	api := fakeapi.New(testToken)
+	api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.HasSuffix(r.URL.Path, "/ticket_form_fields") && failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}
+		return false
+	}
+	p := startPluginWithApi(t, api, "max_retry_attempts = 1")
+
+	// the failure isn't cached, so the form is read again by the next query
+	if _, err := p.query(t, "freshservice_ticket", nil, 0); err == nil {
+		t.Fatal("expected the query to fail whilst the form can't be read")
+	}
+	assertRow(t, p.mustQuery(t, "freshservice_ticket", nil, 0), row{"id": int64(2), "status_desc": "Awaiting Vendor", "priority_desc": "P3"})
+}
@@ -0,0 +1,120 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// debugBodySize is the most of a response body logged when debug_http_bodies is set.
+const debugBodySize = 2048
+
+// rateLimitHeaders are the response headers describing the API quota of the account, these are always logged.
+var rateLimitHeaders = []string{"X-Ratelimit-Total", "X-Ratelimit-Remaining", "X-Ratelimit-Used-Currentrequest", "Retry-After"}
+
+// loggingTransport logs every request sent to FreshService, along with the status & timing of its response. Requests
+// are logged at DEBUG level, or at WARN level when debug_http is set so that they appear without changing the log level
+// of Steampipe. The token is always redacted.
+type loggingTransport struct {
+	base    http.RoundTripper
+	baseUrl *url.URL
+	always  bool
+	bodies  bool
+}
+
+func newLoggingTransport(config PluginConfig, baseUrl *url.URL, base http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		base:    base,
+		baseUrl: baseUrl,
+		always:  config.DebugHttp != nil && *config.DebugHttp,
+		bodies:  config.DebugHttpBodies != nil && *config.DebugHttpBodies,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := contextLogger(req.Context())
+	if logger == nil || (!t.always && !logger.IsDebug()) {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	token, _, _ := req.BasicAuth()
+	redact := func(s string) string {
+		if token == "" {
+			return s
+		}
+		return strings.ReplaceAll(s, token, redactedToken)
+	}
+
+	fields := []interface{}{
+		"method", req.Method,
+		"path", strings.TrimPrefix(req.URL.Path, t.baseUrl.Path),
+		"query", redact(req.URL.RawQuery),
+		"headers", redactHeaders(req.Header, redact),
+		"duration_ms", elapsed.Milliseconds(),
+	}
+
+	if err != nil {
+		fields = append(fields, "error", redact(err.Error()))
+	} else {
+		fields = append(fields, "status", res.StatusCode)
+		for _, h := range rateLimitHeaders {
+			if v := res.Header.Get(h); v != "" {
+				fields = append(fields, strings.ToLower(h), v)
+			}
+		}
+
+		if t.bodies {
+			body, readErr := io.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr != nil {
+				err = readErr
+				res = nil
+			}
+
+			if len(body) > debugBodySize {
+				body = append(body[:debugBodySize:debugBodySize], []byte("...")...)
+			}
+			fields = append(fields, "body", redact(string(body)))
+		}
+	}
+
+	if t.always {
+		logger.Warn("freshservice_http", fields...)
+	} else {
+		logger.Debug("freshservice_http", fields...)
+	}
+
+	return res, err
+}
+
+// redactHeaders returns the headers of a request as a map for logging, with the credentials removed.
+func redactHeaders(header http.Header, redact func(string) string) map[string]string {
+	out := make(map[string]string, len(header))
+	for k := range header {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Proxy-Authorization", "Cookie":
+			out[k] = redactedToken
+		default:
+			out[k] = redact(header.Get(k))
+		}
+	}
+
+	return out
+}
+
+// contextLogger returns the logger Steampipe adds to the context of a query, or nil outside of a query.
+func contextLogger(ctx context.Context) hclog.Logger {
+	logger, _ := ctx.Value(context_key.Logger).(hclog.Logger)
+	return logger
+}
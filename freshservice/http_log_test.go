@@ -0,0 +1,97 @@
+package freshservice
+
+import (
+	"bytes"
+	"context"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+	"net/http"
+	"net/url"
+	"steampipe-plugin-freshservice/freshservice/internal/fakeapi"
+	"strings"
+	"testing"
+)
+
+func TestHttpLogging(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   PluginConfig
+		level    hclog.Level
+		logged   bool
+		contains []string
+	}{
+		{name: "disabled", level: hclog.Warn, logged: false},
+		{
+			name:     "debug_http",
+			config:   PluginConfig{DebugHttp: ptr(true)},
+			level:    hclog.Warn,
+			logged:   true,
+			contains: []string{"[WARN]", "method=GET", "path=tickets", "per_page=100", "status=200", "x-ratelimit-remaining=99", "duration_ms="},
+		},
+		{
+			name:     "debug log level",
+			level:    hclog.Debug,
+			logged:   true,
+			contains: []string{"[DEBUG]", "path=tickets", "status=200"},
+		},
+		{
+			name:     "bodies",
+			config:   PluginConfig{DebugHttp: ptr(true), DebugHttpBodies: ptr(true)},
+			level:    hclog.Warn,
+			logged:   true,
+			contains: []string{"Cannot login", "token REDACTED"},
+		},
+	}
+
+	for _, tt := range tests {
+		api := fakeapi.New(testToken)
+		api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+			w.Header().Set("X-Ratelimit-Total", "100")
+			w.Header().Set("X-Ratelimit-Remaining", "99")
+			w.Header().Set("X-Ratelimit-Used-Currentrequest", "1")
+			if r.URL.Query().Get("echo") != "" {
+				_, _ = w.Write([]byte(`{"tickets":[{"subject":"Cannot login","description":"token ` + testToken + `"}]}`))
+				return true
+			}
+			return false
+		}
+
+		baseUrl, _ := url.Parse(api.BaseUrl())
+		client, err := newApiClient(context.Background(), baseUrl, staticToken(testToken), tt.config, connectionRateLimiter(t.Name()+tt.name, PluginConfig{RequestsPerMinute: ptr(60000)}), connectionUsage(t.Name()+tt.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := new(bytes.Buffer)
+		logger := hclog.New(&hclog.LoggerOptions{Output: out, Level: tt.level})
+		ctx := context.WithValue(context.Background(), context_key.Logger, logger)
+
+		query := url.Values{"per_page": {"100"}}
+		if tt.config.DebugHttpBodies != nil {
+			query.Set("echo", "1")
+		}
+		if _, err = client.list(ctx, "tickets", query, new(map[string]interface{})); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		api.Close()
+
+		logs := out.String()
+		if !tt.logged && logs != "" {
+			t.Errorf("%s: expected nothing to be logged, got %s", tt.name, logs)
+		}
+		if tt.logged && !strings.Contains(logs, "freshservice_http") {
+			t.Errorf("%s: expected the request to be logged, got %s", tt.name, logs)
+		}
+		for _, s := range tt.contains {
+			if !strings.Contains(logs, s) {
+				t.Errorf("%s: expected the log to contain '%s', got %s", tt.name, s, logs)
+			}
+		}
+		if strings.Contains(logs, testToken) {
+			t.Errorf("%s: expected the token to be redacted, got %s", tt.name, logs)
+		}
+		if tt.logged && !strings.Contains(logs, "Authorization:"+redactedToken) {
+			t.Errorf("%s: expected the authorization header to be redacted, got %s", tt.name, logs)
+		}
+	}
+}
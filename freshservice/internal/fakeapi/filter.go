@@ -0,0 +1,200 @@
+package fakeapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterFields maps the fields of a filter query to the field of the items they compare, where the two differ.
+var filterFields = map[string]string{
+	"agent_id": "responder_id",
+	"tag":      "tags",
+}
+
+// datePattern matches the date values of a filter query, which are compared with the date of a timestamp.
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// predicate reports whether an item matches (part of) a filter query.
+type predicate func(item map[string]interface{}) bool
+
+// parseFilter parses a query of the filter API, such as "priority:>3 AND (status:2 OR status:3)", which must be
+// enclosed in double quotes. As with FreshService the > & < operators include the value, dates are compared by day.
+func parseFilter(query string) (predicate, error) {
+	if len(query) < 2 || !strings.HasPrefix(query, `"`) || !strings.HasSuffix(query, `"`) {
+		return nil, fmt.Errorf("the query %s must be enclosed in double quotes", query)
+	}
+
+	p := &filterParser{s: query[1 : len(query)-1]}
+	match, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("unexpected %q at position %d of the query", p.s[p.pos:], p.pos)
+	}
+
+	return match, nil
+}
+
+type filterParser struct {
+	s   string
+	pos int
+}
+
+func (p *filterParser) or() (predicate, error) {
+	left, err := p.and()
+	for err == nil && p.consume(" OR ") {
+		var right predicate
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(item map[string]interface{}) bool { return l(item) || right(item) }
+		}
+	}
+
+	return left, err
+}
+
+func (p *filterParser) and() (predicate, error) {
+	left, err := p.term()
+	for err == nil && p.consume(" AND ") {
+		var right predicate
+		if right, err = p.term(); err == nil {
+			l := left
+			left = func(item map[string]interface{}) bool { return l(item) && right(item) }
+		}
+	}
+
+	return left, err
+}
+
+func (p *filterParser) term() (predicate, error) {
+	if !p.consume("(") {
+		return p.condition()
+	}
+
+	match, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(")") {
+		return nil, fmt.Errorf("missing ) at position %d of the query", p.pos)
+	}
+
+	return match, nil
+}
+
+// condition parses a condition such as status:2, priority:>3 or tag:'login'.
+func (p *filterParser) condition() (predicate, error) {
+	colon := strings.IndexByte(p.s[p.pos:], ':')
+	if colon <= 0 {
+		return nil, fmt.Errorf("expected a condition at position %d of the query", p.pos)
+	}
+	field := p.s[p.pos : p.pos+colon]
+	p.pos += colon + 1
+
+	operator := "="
+	if p.consume(">") {
+		operator = ">"
+	} else if p.consume("<") {
+		operator = "<"
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if f, ok := filterFields[field]; ok {
+		field = f
+	}
+
+	return func(item map[string]interface{}) bool {
+		return compare(item[field], operator, value)
+	}, nil
+}
+
+// value parses a quoted string (in which \ escapes the next character) or an unquoted number.
+func (p *filterParser) value() (string, error) {
+	if !p.consume("'") {
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] != ' ' && p.s[p.pos] != ')' {
+			p.pos++
+		}
+		return p.s[start:p.pos], nil
+	}
+
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.s):
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		case c == '\'':
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated value in the query")
+}
+
+func (p *filterParser) consume(token string) bool {
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+// compare compares the field of an item with the value of a condition, a condition on a list (such as the tags of a
+// ticket) matches if any element matches.
+func compare(field interface{}, operator string, value string) bool {
+	if list, ok := field.([]interface{}); ok {
+		for _, v := range list {
+			if compare(v, operator, value) {
+				return true
+			}
+		}
+		return false
+	}
+	if field == nil {
+		return false
+	}
+
+	actual := fmt.Sprint(field)
+	var cmp int
+	switch {
+	case datePattern.MatchString(value):
+		if len(actual) < len(value) {
+			return false
+		}
+		cmp = strings.Compare(actual[:len(value)], value)
+	default:
+		a, errA := strconv.ParseFloat(actual, 64)
+		v, errV := strconv.ParseFloat(value, 64)
+		if errA != nil || errV != nil {
+			return operator == "=" && actual == value
+		}
+		switch {
+		case a < v:
+			cmp = -1
+		case a > v:
+			cmp = 1
+		}
+	}
+
+	switch operator {
+	case ">":
+		return cmp >= 0
+	case "<":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
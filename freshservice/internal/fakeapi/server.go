@@ -0,0 +1,528 @@
+// Package fakeapi provides a fake FreshService API for testing the plugin offline.
+//
+// Responses are served from the JSON fixtures in testdata, which mirror the paths of the API: a request to
+// /api/v2/tickets is served from testdata/tickets.json and a request to /api/v2/tickets/1/tasks from
+// testdata/tickets/1/tasks.json. A request for a single resource, such as /api/v2/tickets/1, is answered with the
+// matching item of the parent collection.
+package fakeapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiPrefix is the path of the FreshService API served by the Server.
+const apiPrefix = "/api/v2/"
+
+// defaultPerPage is the page size of the FreshService API when no per_page parameter is passed.
+const defaultPerPage = 30
+
+//go:embed testdata
+var fixtures embed.FS
+
+// singular maps the root element of a collection to the root element used when the API returns a single item.
+var singular = map[string]string{
+	"agents":            "agent",
+	"announcements":     "announcement",
+	"application_users": "application_user",
+	"applications":      "application",
+	"articles":          "article",
+	"asset_types":       "asset_type",
+	"assets":            "asset",
+	"business_hours":    "business_hours",
+	"categories":        "category",
+	"changes":           "change",
+	"contracts":         "contract",
+	"custom_objects":    "custom_object",
+	"departments":       "department",
+	"folders":           "folder",
+	"locations":         "location",
+	"problems":          "problem",
+	"products":          "product",
+	"purchase_orders":   "purchase_order",
+	"releases":          "release",
+	"requesters":        "requester",
+	"roles":             "role",
+	"service_items":     "service_item",
+	"tickets":           "ticket",
+	"vendors":           "vendor",
+	"workspaces":        "workspace",
+}
+
+// lookupKeys holds the field used to find a single item where this is not the id of the item.
+var lookupKeys = map[string]string{
+	"assets": "display_id",
+}
+
+// paramFields maps query parameters to the field they filter on where the two differ, by collection.
+var paramFields = map[string]map[string]string{
+	"requesters": {"email": "primary_email"},
+}
+
+// wildcardParams holds the value of a query parameter which matches every item, workspace_id 0 lists the items of
+// every workspace.
+var wildcardParams = map[string]string{
+	"workspace_id": "0",
+}
+
+// sinceParams maps query parameters which keep the items with a timestamp at or after their value to the field of
+// that timestamp.
+var sinceParams = map[string]string{
+	"updated_since": "updated_at",
+}
+
+// ignoredParams are query parameters which are not matched against the fields of the items, as they control
+// pagination or are handled separately.
+var ignoredParams = map[string]bool{
+	"page":            true,
+	"per_page":        true,
+	"include_agents":  true,
+	"include":         true,
+	"filter":          true,
+	"query":           true,
+	"page_size":       true,
+	"next_page_token": true,
+}
+
+// includedFields are only returned when named by the include query parameter, as FreshService does.
+var includedFields = map[string]bool{
+	"type_fields": true,
+}
+
+// Server is a fake FreshService API, every request must be authenticated with Token.
+type Server struct {
+	*httptest.Server
+
+	// Token is the api key expected as the username of the basic auth credentials.
+	Token string
+	// MaxPerPage is the largest number of items returned in a single page.
+	MaxPerPage int
+	// Intercept, when set, is called for every request before it is authenticated. Returning true means Intercept
+	// has written the response, allowing tests to inspect requests or simulate failures of the API.
+	Intercept func(w http.ResponseWriter, r *http.Request) bool
+
+	mu       sync.Mutex
+	requests []*url.URL
+}
+
+// New starts a Server accepting token, the caller should Close the Server when done.
+func New(token string) *Server {
+	s := &Server{
+		Token:      token,
+		MaxPerPage: 100,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// NewTLS starts a Server accepting token over https, with a certificate signed by the Certificate of the Server.
+func NewTLS(token string) *Server {
+	s := &Server{
+		Token:      token,
+		MaxPerPage: 100,
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// BaseUrl returns the url of the API served by the Server.
+func (s *Server) BaseUrl() string {
+	return s.URL + apiPrefix
+}
+
+// Requests returns the url of every request received since the Server was started or last Reset.
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*url.URL, len(s.requests))
+	copy(out, s.requests)
+
+	return out
+}
+
+// RequestsTo returns the requests received for the API path p (for example "tickets").
+func (s *Server) RequestsTo(p string) []*url.URL {
+	var out []*url.URL
+	for _, u := range s.Requests() {
+		if strings.TrimPrefix(u.Path, apiPrefix) == p {
+			out = append(out, u)
+		}
+	}
+
+	return out
+}
+
+// Reset forgets all requests received so far.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL)
+	intercept := s.Intercept
+	s.mu.Unlock()
+
+	if intercept != nil && intercept(w, r) {
+		return
+	}
+
+	if user, _, ok := r.BasicAuth(); !ok || user != s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid_credentials", "You have to be logged in to perform this action.")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "The fake API only supports GET requests.")
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not_found", "The requested resource does not exist.")
+		return
+	}
+	p := strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+
+	if parent, ok := strings.CutSuffix(p, "/filter"); ok {
+		s.writeFiltered(w, r, parent)
+		return
+	}
+
+	if root, items, err := loadCollection(p); err == nil {
+		s.writeCollection(w, r, root, items)
+		return
+	}
+
+	if root, item, ok := findItem(p); ok {
+		omitUnincluded(item, r.URL.Query().Get("include"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{singular[root]: item}, nil)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "The requested resource does not exist.")
+}
+
+// writeCollection filters items on the query parameters of r before writing the requested page.
+func (s *Server) writeCollection(w http.ResponseWriter, r *http.Request, root string, items []map[string]interface{}) {
+	q := r.URL.Query()
+	items = filter(items, q, paramFields[root])
+
+	items, err := filterQuery(items, q.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_value", err.Error())
+		return
+	}
+	if items, err = matchConditions(items, q.Get("query")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_value", err.Error())
+		return
+	}
+	for _, item := range items {
+		omitUnincluded(item, q.Get("include"))
+	}
+
+	// the records of custom objects are paginated by a token within the next_page_link of the response
+	if q.Has("page_size") {
+		s.writeTokenPage(w, r, root, items)
+		return
+	}
+
+	perPage := defaultPerPage
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		perPage = v
+	}
+	if perPage > s.MaxPerPage {
+		perPage = s.MaxPerPage
+	}
+
+	page := 1
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+
+	start := (page - 1) * perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+
+	header := make(http.Header)
+	if end < len(items) {
+		next := *r.URL
+		next.Scheme = "http"
+		if r.TLS != nil {
+			next.Scheme = "https"
+		}
+		next.Host = r.Host
+		nq := next.Query()
+		nq.Set("page", strconv.Itoa(page+1))
+		nq.Set("per_page", strconv.Itoa(perPage))
+		next.RawQuery = nq.Encode()
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{root: items[start:end]}, header)
+}
+
+// writeFiltered writes the items of the collection at p matching the query parameter of r, as the filter API (such as
+// tickets/filter) does.
+func (s *Server) writeFiltered(w http.ResponseWriter, r *http.Request, p string) {
+	root, items, err := loadCollection(p)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "The requested resource does not exist.")
+		return
+	}
+
+	match, err := parseFilter(r.URL.Query().Get("query"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_value", err.Error())
+		return
+	}
+
+	matched := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if match(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	// the remaining parameters (such as the page & workspace_id) apply as for the collection
+	r = r.Clone(r.Context())
+	q := r.URL.Query()
+	q.Del("query")
+	r.URL.RawQuery = q.Encode()
+
+	s.writeCollection(w, r, root, matched)
+}
+
+// writeTokenPage writes the page of items starting at the next_page_token of r (an offset), the response holds a
+// next_page_link to the following page unless it is the last. Like FreshService, the link only holds the page size &
+// token, the query of r must be sent again with it.
+func (s *Server) writeTokenPage(w http.ResponseWriter, r *http.Request, root string, items []map[string]interface{}) {
+	q := r.URL.Query()
+
+	pageSize := defaultPerPage
+	if v, err := strconv.Atoi(q.Get("page_size")); err == nil && v > 0 {
+		pageSize = v
+	}
+	if pageSize > s.MaxPerPage {
+		pageSize = s.MaxPerPage
+	}
+
+	start := 0
+	if t := q.Get("next_page_token"); t != "" {
+		v, err := strconv.Atoi(t)
+		if err != nil || v < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_value", "The next_page_token is invalid.")
+			return
+		}
+		start = v
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	body := map[string]interface{}{root: items[start:end]}
+	if end < len(items) {
+		next := url.Values{"page_size": {q.Get("page_size")}, "next_page_token": {strconv.Itoa(end)}}
+		body["next_page_link"] = r.URL.Path + "?" + next.Encode()
+	}
+
+	writeJSON(w, http.StatusOK, body, nil)
+}
+
+// loadCollection reads the fixture for the collection at p, returning its root element and items.
+func loadCollection(p string) (string, []map[string]interface{}, error) {
+	raw, err := fs.ReadFile(fixtures, path.Join("testdata", p+".json"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	wrapper := make(map[string][]map[string]interface{})
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err = dec.Decode(&wrapper); err != nil {
+		return "", nil, fmt.Errorf("fixture %s is invalid: %v", p, err)
+	}
+
+	for root, items := range wrapper {
+		return root, items, nil
+	}
+
+	return "", nil, fmt.Errorf("fixture %s is empty", p)
+}
+
+// findItem looks up the single item at p (such as tickets/1) in the fixture of its parent collection.
+func findItem(p string) (string, map[string]interface{}, bool) {
+	parent, id := path.Split(p)
+	if _, err := strconv.Atoi(id); err != nil {
+		return "", nil, false
+	}
+
+	root, items, err := loadCollection(strings.TrimSuffix(parent, "/"))
+	if err != nil {
+		return "", nil, false
+	}
+
+	key := "id"
+	if k, ok := lookupKeys[root]; ok {
+		key = k
+	}
+
+	for _, item := range items {
+		if fmt.Sprint(item[key]) == id {
+			return root, item, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// filter keeps the items matching every query parameter which corresponds to a field of the items.
+func filter(items []map[string]interface{}, q url.Values, fields map[string]string) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		match := true
+		for k := range q {
+			if ignoredParams[k] {
+				continue
+			}
+			if v, ok := wildcardParams[k]; ok && q.Get(k) == v {
+				continue
+			}
+			if f, ok := sinceParams[k]; ok {
+				if !since(item[f], q.Get(k)) {
+					match = false
+					break
+				}
+				continue
+			}
+			field := k
+			if f, ok := fields[k]; ok {
+				field = f
+			}
+			if v, ok := item[field]; ok && fmt.Sprint(v) != q.Get(k) {
+				match = false
+				break
+			}
+		}
+		if match {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+// since reports whether the timestamp of an item is at or after value, an item without a valid timestamp is kept.
+func since(timestamp interface{}, value string) bool {
+	at, err := time.Parse(time.RFC3339, fmt.Sprint(timestamp))
+	if err != nil {
+		return true
+	}
+	from, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return true
+	}
+
+	return !at.Before(from)
+}
+
+// filterQuery keeps the items matching a filter query such as "asset_type_id:2 AND name:'Printer'", which must be
+// enclosed in double quotes.
+func filterQuery(items []map[string]interface{}, query string) ([]map[string]interface{}, error) {
+	if query == "" {
+		return items, nil
+	}
+	if len(query) < 2 || !strings.HasPrefix(query, `"`) || !strings.HasSuffix(query, `"`) {
+		return nil, fmt.Errorf("the filter query %s must be enclosed in double quotes", query)
+	}
+
+	return matchConditions(items, query[1:len(query)-1])
+}
+
+// queryValueUnescaper unescapes the quoted values of a query, in which \ escapes the next character.
+var queryValueUnescaper = strings.NewReplacer(`\\`, `\`, `\'`, `'`)
+
+// matchConditions keeps the items matching every condition of expr, such as "status : 'Active' AND tier : 1". Only
+// equality conditions joined by AND are supported.
+func matchConditions(items []map[string]interface{}, expr string) ([]map[string]interface{}, error) {
+	if expr == "" {
+		return items, nil
+	}
+
+	conditions := make(map[string]string)
+	for _, condition := range strings.Split(expr, " AND ") {
+		field, value, ok := strings.Cut(condition, ":")
+		if !ok {
+			return nil, fmt.Errorf("the condition %s of the query is invalid", condition)
+		}
+		conditions[strings.TrimSpace(field)] = queryValueUnescaper.Replace(strings.Trim(strings.TrimSpace(value), "'"))
+	}
+
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		match := true
+		for field, value := range conditions {
+			if fmt.Sprint(item[field]) != value {
+				match = false
+				break
+			}
+		}
+		if match {
+			out = append(out, item)
+		}
+	}
+
+	return out, nil
+}
+
+// omitUnincluded removes the includedFields of item which were not named in include.
+func omitUnincluded(item map[string]interface{}, include string) {
+	requested := make(map[string]bool)
+	for _, f := range strings.Split(include, ",") {
+		requested[f] = true
+	}
+
+	for f := range includedFields {
+		if !requested[f] {
+			delete(item, f)
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message}, nil)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}, header http.Header) {
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
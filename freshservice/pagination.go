@@ -0,0 +1,174 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/go-querystring/query"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxPerPage is the largest page size accepted by the FreshService API.
+const maxPerPage = 100
+
+// paginate streams every item of the collection at path, requesting pages of up to maxPerPage items until either the
+// collection is exhausted or the query requires no further rows. page must point to the ListOptions embedded in opt,
+// items extracts the collection from a decoded page.
+//
+// When the connection has page_prefetch set, the following pages are requested concurrently whilst the current page
+// is being streamed (all requests still pass through the rate limiter of the connection).
+func paginate[P any, T any](ctx context.Context, d *plugin.QueryData, client *apiClient, path string, opt interface{}, page *fs.ListOptions, items func(*P) []T) error {
+	remaining := d.RowsRemaining(ctx)
+	if remaining <= 0 {
+		return nil
+	}
+
+	page.Page = 1
+	page.PerPage = maxPerPage
+	if remaining < maxPerPage {
+		page.PerPage = int(remaining)
+	}
+
+	values, err := query.Values(opt)
+	if err != nil {
+		return fmt.Errorf("error creating query string for request: %v", err)
+	}
+
+	// cancelling ctx abandons any prefetched pages which are no longer required
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	inFlight := make(map[int]<-chan pageResult[P])
+	fetch := func(n int) <-chan pageResult[P] {
+		if ch, ok := inFlight[n]; ok {
+			delete(inFlight, n)
+			return ch
+		}
+
+		q := make(url.Values, len(values))
+		for k, v := range values {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(n))
+
+		ch := make(chan pageResult[P], 1)
+		go func() {
+			p := new(P)
+			res, err := client.list(ctx, path, q, p)
+			ch <- pageResult[P]{page: p, res: res, err: err}
+		}()
+		return ch
+	}
+
+	current := 1
+	for {
+		// stop paging as soon as the query is cancelled, rather than fetching the remaining pages in the background
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		result := <-fetch(current)
+		if result.err != nil {
+			return result.err
+		}
+
+		pageItems := items(result.page)
+		next, hasNext := nextPage(result.res.Header.Get("Link"))
+
+		if hasNext {
+			// only prefetch as many pages as could be required to satisfy the query
+			ahead := int64(client.pagePrefetch)
+			if needed := (d.RowsRemaining(ctx) - int64(len(pageItems)) + int64(page.PerPage) - 1) / int64(page.PerPage); needed < ahead {
+				ahead = needed
+			}
+
+			for n := next; n < next+int(ahead); n++ {
+				if _, ok := inFlight[n]; !ok {
+					inFlight[n] = fetch(n)
+				}
+			}
+		}
+
+		for _, item := range pageItems {
+			d.StreamListItem(ctx, item)
+
+			if d.RowsRemaining(ctx) <= 0 {
+				return nil
+			}
+		}
+
+		if !hasNext {
+			return nil
+		}
+
+		current = next
+	}
+}
+
+// listAll returns every item of the collection at path, for reading the metadata of an instance (such as its asset
+// types) when a connection is loaded rather than streaming the rows of a query.
+func listAll[P any, T any](ctx context.Context, client *apiClient, path string, items func(*P) []T) ([]T, error) {
+	var all []T
+
+	for page := 1; ; {
+		q := url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(maxPerPage)}}
+
+		p := new(P)
+		res, err := client.list(ctx, path, q, p)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items(p)...)
+
+		next, ok := nextPage(res.Header.Get("Link"))
+		if !ok {
+			return all, nil
+		}
+		page = next
+	}
+}
+
+type pageResult[P any] struct {
+	page *P
+	res  *http.Response
+	err  error
+}
+
+// nextPage obtains the page number of the rel="next" entry in a Link header, for example:
+// <https://domain.freshservice.com/api/v2/tickets?page=2&per_page=100>; rel="next"
+func nextPage(link string) (int, bool) {
+	for _, entry := range strings.Split(link, ",") {
+		parts := strings.Split(entry, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		isNext := false
+		for _, param := range parts[1:] {
+			if strings.EqualFold(strings.ReplaceAll(strings.TrimSpace(param), " ", ""), `rel="next"`) {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			return 0, false
+		}
+
+		p, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil || p < 1 {
+			return 0, false
+		}
+
+		return p, true
+	}
+
+	return 0, false
+}
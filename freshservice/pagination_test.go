@@ -0,0 +1,137 @@
+package freshservice
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		maxPerPage int
+		limit      int64
+		rows       int
+		pages      []string
+	}{
+		{name: "single page", maxPerPage: 100, rows: 3, pages: []string{"1"}},
+		{name: "every page", maxPerPage: 1, rows: 3, pages: []string{"1", "2", "3"}},
+		{name: "partial last page", maxPerPage: 2, rows: 3, pages: []string{"1", "2"}},
+		{name: "limit within first page", maxPerPage: 100, limit: 2, rows: 2, pages: []string{"1"}},
+		{name: "limit across pages", maxPerPage: 1, limit: 2, rows: 2, pages: []string{"1", "2"}},
+		{name: "prefetch", config: "page_prefetch = 2", maxPerPage: 1, rows: 3, pages: []string{"1", "2", "3"}},
+		{name: "prefetch within limit", config: "page_prefetch = 5", maxPerPage: 1, limit: 1, rows: 1, pages: []string{"1"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := startPlugin(t, tt.config)
+			p.api.MaxPerPage = tt.maxPerPage
+
+			rows := p.mustQuery(t, "freshservice_ticket", nil, tt.limit)
+			if len(rows) != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+
+			requested := make(map[string]int)
+			for _, r := range p.api.RequestsTo("tickets") {
+				requested[r.Query().Get("page")]++
+
+				perPage := r.Query().Get("per_page")
+				if tt.limit > 0 && perPage != strconv.FormatInt(tt.limit, 10) {
+					t.Errorf("expected per_page to be the limit of %d, got %s", tt.limit, perPage)
+				}
+			}
+
+			if len(requested) != len(tt.pages) {
+				t.Errorf("expected pages %v to be requested, got %v", tt.pages, requested)
+			}
+			for _, page := range tt.pages {
+				if requested[page] != 1 {
+					t.Errorf("expected page %s to be requested once, got %d", page, requested[page])
+				}
+			}
+		})
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	p := startPlugin(t, "max_retry_attempts = 1")
+	p.api.MaxPerPage = 1
+
+	started := make(chan struct{})
+	abandoned := make(chan struct{})
+	p.api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Query().Get("page") != "2" {
+			return false
+		}
+
+		// stall the second page until the plugin gives up on the request
+		close(started)
+		select {
+		case <-r.Context().Done():
+			close(abandoned)
+		case <-time.After(10 * time.Second):
+		}
+		return true
+	}
+
+	stream, cancel, err := p.execute(t, "freshservice_ticket", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the second page to be requested")
+	}
+	cancel()
+
+	select {
+	case <-abandoned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the in-flight request to be abandoned when the query was cancelled")
+	}
+
+	// give the plugin the chance to (incorrectly) carry on paging
+	time.Sleep(200 * time.Millisecond)
+	for _, r := range p.api.RequestsTo("tickets") {
+		if page := r.Query().Get("page"); page != "1" && page != "2" {
+			t.Errorf("expected no further pages to be requested after the query was cancelled, got page %s", page)
+		}
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	tests := []struct {
+		link string
+		page int
+		ok   bool
+	}{
+		{link: "", ok: false},
+		{link: `<https://test.freshservice.com/api/v2/tickets?page=2&per_page=100>; rel="next"`, page: 2, ok: true},
+		{link: `<https://test.freshservice.com/api/v2/tickets?page=1>; rel="prev", <https://test.freshservice.com/api/v2/tickets?page=3>; rel="next"`, page: 3, ok: true},
+		{link: `<https://test.freshservice.com/api/v2/tickets?page=1>; rel="prev"`, ok: false},
+		{link: `<https://test.freshservice.com/api/v2/tickets>; rel="next"`, ok: false},
+	}
+
+	for _, tt := range tests {
+		page, ok := nextPage(tt.link)
+		if page != tt.page || ok != tt.ok {
+			t.Errorf("nextPage(%q) = %d, %t; expected %d, %t", tt.link, page, ok, tt.page, tt.ok)
+		}
+	}
+}
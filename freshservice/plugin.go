@@ -14,47 +14,85 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			Schema:      ConfigSchema,
 		},
 		DefaultTransform: transform.FromGo(),
-		TableMap: map[string]*plugin.Table{
-			"freshservice_agent":                 tableAgent(),
-			"freshservice_agent_role":            tableAgentRole(),
-			"freshservice_announcement":          tableAnnouncement(),
-			"freshservice_asset":                 tableAsset(),
-			"freshservice_asset_component":       tableAssetComponent(),
-			"freshservice_asset_contract":        tableAssetContract(),
-			"freshservice_asset_type":            tableAssetType(),
-			"freshservice_business_hour":         tableBusinessHour(),
-			"freshservice_change":                tableChange(),
-			"freshservice_change_note":           tableChangeNote(),
-			"freshservice_contract":              tableContract(),
-			"freshservice_contract_type":         tableContractType(),
-			"freshservice_department":            tableDepartment(),
-			"freshservice_location":              tableLocation(),
-			"freshservice_requester":             tableRequester(),
-			"freshservice_problem":               tableProblem(),
-			"freshservice_problem_note":          tableProblemNote(),
-			"freshservice_problem_task":          tableProblemTask(),
-			"freshservice_problem_timeentry":     tableProblemTimeEntry(),
-			"freshservice_product":               tableProduct(),
-			"freshservice_purchase_order":        tablePurchaseOrder(),
-			"freshservice_release":               tableRelease(),
-			"freshservice_release_note":          tableReleaseNote(),
-			"freshservice_release_task":          tableReleaseTask(),
-			"freshservice_release_timeentry":     tableReleaseTimeEntry(),
-			"freshservice_service":               tableService(),
-			"freshservice_sla_policy":            tableSlaPolicy(),
-			"freshservice_software":              tableSoftware(),
-			"freshservice_software_installation": tableSoftwareInstallation(),
-			"freshservice_software_user":         tableSoftwareUser(),
-			"freshservice_solution_article":      tableSolutionArticle(),
-			"freshservice_solution_category":     tableSolutionCategory(),
-			"freshservice_solution_folder":       tableSolutionFolder(),
-			"freshservice_ticket":                tableTicket(),
-			"freshservice_ticket_conversation":   tableTicketConversation(),
-			"freshservice_ticket_task":           tableTicketTask(),
-			"freshservice_ticket_timeentry":      tableTicketTimeEntry(),
-			"freshservice_vendor":                tableVendor(),
+		DefaultIgnoreConfig: &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreNotFound,
 		},
+		SchemaMode:   plugin.SchemaModeDynamic,
+		TableMapFunc: pluginTableMap,
 	}
 
 	return p
 }
+
+// pluginTableMap returns the tables of a connection. When the connection has custom_field_columns set, the custom
+// fields of the ticket, change, problem & release forms are added to their tables as typed columns. When it has
+// asset_type_tables set, a table is added for each asset type, and with custom_object_tables a table is added for each
+// custom object.
+func pluginTableMap(ctx context.Context, d *plugin.TableMapData) (map[string]*plugin.Table, error) {
+	tables := tableMap()
+
+	config := GetConfig(d.Connection)
+	if config.CustomFieldColumns != nil && *config.CustomFieldColumns {
+		addCustomFieldColumns(ctx, d, tables)
+	}
+	if config.AssetTypeTables != nil && *config.AssetTypeTables {
+		addAssetTypeTables(ctx, d, tables)
+	}
+	if config.CustomObjectTables != nil && *config.CustomObjectTables {
+		addCustomObjectTables(ctx, d, tables)
+	}
+
+	return tables, nil
+}
+
+// tableMap returns every table of the plugin with its static columns.
+func tableMap() map[string]*plugin.Table {
+	tables := map[string]*plugin.Table{
+		"freshservice_agent":                 tableAgent(),
+		"freshservice_agent_role":            tableAgentRole(),
+		"freshservice_announcement":          tableAnnouncement(),
+		"freshservice_api_usage":             tableApiUsage(),
+		"freshservice_asset":                 tableAsset(),
+		"freshservice_asset_component":       tableAssetComponent(),
+		"freshservice_asset_contract":        tableAssetContract(),
+		"freshservice_asset_type":            tableAssetType(),
+		"freshservice_business_hour":         tableBusinessHour(),
+		"freshservice_change":                tableChange(),
+		"freshservice_change_note":           tableChangeNote(),
+		"freshservice_contract":              tableContract(),
+		"freshservice_contract_type":         tableContractType(),
+		"freshservice_department":            tableDepartment(),
+		"freshservice_location":              tableLocation(),
+		"freshservice_requester":             tableRequester(),
+		"freshservice_problem":               tableProblem(),
+		"freshservice_problem_note":          tableProblemNote(),
+		"freshservice_problem_task":          tableProblemTask(),
+		"freshservice_problem_timeentry":     tableProblemTimeEntry(),
+		"freshservice_product":               tableProduct(),
+		"freshservice_purchase_order":        tablePurchaseOrder(),
+		"freshservice_release":               tableRelease(),
+		"freshservice_release_note":          tableReleaseNote(),
+		"freshservice_release_task":          tableReleaseTask(),
+		"freshservice_release_timeentry":     tableReleaseTimeEntry(),
+		"freshservice_service":               tableService(),
+		"freshservice_sla_policy":            tableSlaPolicy(),
+		"freshservice_software":              tableSoftware(),
+		"freshservice_software_installation": tableSoftwareInstallation(),
+		"freshservice_software_user":         tableSoftwareUser(),
+		"freshservice_solution_article":      tableSolutionArticle(),
+		"freshservice_solution_category":     tableSolutionCategory(),
+		"freshservice_solution_folder":       tableSolutionFolder(),
+		"freshservice_ticket":                tableTicket(),
+		"freshservice_ticket_conversation":   tableTicketConversation(),
+		"freshservice_ticket_task":           tableTicketTask(),
+		"freshservice_ticket_timeentry":      tableTicketTimeEntry(),
+		"freshservice_vendor":                tableVendor(),
+		"freshservice_workspace":             tableWorkspace(),
+	}
+
+	for _, table := range tables {
+		withDomainColumn(table)
+	}
+
+	return tables
+}
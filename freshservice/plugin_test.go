@@ -0,0 +1,317 @@
+package freshservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/go-hclog"
+	goPlugin "github.com/hashicorp/go-plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	pluginShared "github.com/turbot/steampipe-plugin-sdk/v5/grpc/shared"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"steampipe-plugin-freshservice/freshservice/internal/fakeapi"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testConnection = "freshservice"
+	testToken      = "test-token"
+
+	// testServeEnv is set for the plugin process started by the tests.
+	testServeEnv = "FRESHSERVICE_TEST_SERVE"
+)
+
+// TestMain serves the plugin instead of running the tests when the test binary is started by startPlugin.
+func TestMain(m *testing.M) {
+	if os.Getenv(testServeEnv) != "" {
+		plugin.Serve(&plugin.ServeOpts{PluginFunc: Plugin})
+		return
+	}
+
+	// silence the trace logging of the plugin client
+	log.SetOutput(io.Discard)
+
+	os.Exit(m.Run())
+}
+
+type row map[string]interface{}
+
+// testPlugin is a running instance of the plugin with a connection to a fake API, or an aggregator of connections to
+// several fake APIs.
+type testPlugin struct {
+	api         *fakeapi.Server
+	client      *grpc.PluginClient
+	calls       int
+	connection  string
+	connections []string
+}
+
+// startPlugin starts the fake API and serves the plugin from a child process, in the same way as Steampipe does.
+// config holds additional connection settings in HCL.
+func startPlugin(t *testing.T, config string) *testPlugin {
+	t.Helper()
+
+	return startPluginWithApi(t, fakeapi.New(testToken), config)
+}
+
+// startPluginWithApi serves the plugin with a connection to api, which is closed when the test completes.
+func startPluginWithApi(t *testing.T, api *fakeapi.Server, config string) *testPlugin {
+	t.Helper()
+	t.Cleanup(api.Close)
+
+	client := servePlugin(t)
+	_, err := client.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+		Configs:        []*proto.ConnectionConfig{connectionConfig(testConnection, api, config)},
+		MaxCacheSizeMb: 16,
+	})
+	if err != nil {
+		t.Fatalf("unable to configure connection: %v", err)
+	}
+
+	return &testPlugin{api: api, client: client, connection: testConnection, connections: []string{testConnection}}
+}
+
+// startAggregator serves the plugin with a connection to each of apis and an aggregator of those connections, which
+// is the connection queried. The apis are closed when the test completes.
+func startAggregator(t *testing.T, apis ...*fakeapi.Server) *testPlugin {
+	t.Helper()
+
+	client := servePlugin(t)
+
+	aggregator := &proto.ConnectionConfig{Connection: testConnection + "_all", Plugin: pluginName(), Type: "aggregator"}
+	configs := []*proto.ConnectionConfig{aggregator}
+	for i, api := range apis {
+		t.Cleanup(api.Close)
+
+		name := fmt.Sprintf("%s_%d", testConnection, i+1)
+		configs = append(configs, connectionConfig(name, api, ""))
+		aggregator.ChildConnections = append(aggregator.ChildConnections, name)
+	}
+
+	_, err := client.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{Configs: configs, MaxCacheSizeMb: 16})
+	if err != nil {
+		t.Fatalf("unable to configure connections: %v", err)
+	}
+
+	return &testPlugin{api: apis[0], client: client, connection: aggregator.Connection, connections: aggregator.ChildConnections}
+}
+
+func pluginName() string {
+	return Plugin(context.Background()).Name
+}
+
+// servePlugin starts the plugin in a child process, which is stopped when the test completes.
+func servePlugin(t *testing.T) *grpc.PluginClient {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), testServeEnv+"=1")
+
+	name := pluginName()
+	client := goPlugin.NewClient(&goPlugin.ClientConfig{
+		HandshakeConfig:  pluginShared.Handshake,
+		Plugins:          map[string]goPlugin.Plugin{name: &pluginShared.WrapperPlugin{}},
+		Cmd:              cmd,
+		AllowedProtocols: []goPlugin.Protocol{goPlugin.ProtocolGRPC},
+		Logger:           hclog.NewNullLogger(),
+	})
+	t.Cleanup(client.Kill)
+
+	pluginClient, err := grpc.NewPluginClient(client, name)
+	if err != nil {
+		t.Fatalf("unable to start plugin: %v", err)
+	}
+
+	return pluginClient
+}
+
+// connectionConfig returns a connection named name to api, with the additional settings in config.
+func connectionConfig(name string, api *fakeapi.Server, config string) *proto.ConnectionConfig {
+	// a config which sets a token_file or token_command supplies its own token
+	credentials := fmt.Sprintf("token = \"%s\"\n", testToken)
+	if strings.Contains(config, "token_") {
+		credentials = ""
+	}
+
+	return &proto.ConnectionConfig{
+		Connection: name,
+		Plugin:     pluginName(),
+		Config:     fmt.Sprintf("base_url = \"%s\"\n%srequests_per_minute = 60000\n%s", api.BaseUrl(), credentials, config),
+	}
+}
+
+// op is a qual with an operator other than =, such as op{">=", 3}. A column may be given several, as []op.
+type op struct {
+	operator string
+	value    interface{}
+}
+
+// query selects every column of table where the columns equal quals, a limit of 0 returns all rows.
+func (p *testPlugin) query(t *testing.T, table string, quals map[string]interface{}, limit int64) ([]row, error) {
+	t.Helper()
+
+	stream, cancel, err := p.execute(t, table, quals, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	var rows []row
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if res.Row == nil {
+			continue
+		}
+
+		r := make(row, len(res.Row.Columns))
+		for name, column := range res.Row.Columns {
+			r[name] = columnValue(column)
+		}
+		rows = append(rows, r)
+	}
+}
+
+// execute starts a query of table in the same way as query, returning the stream of rows and a function which
+// cancels the query.
+func (p *testPlugin) execute(t *testing.T, table string, quals map[string]interface{}, limit int64) (proto.WrapperPlugin_ExecuteClient, context.CancelFunc, error) {
+	t.Helper()
+
+	// the columns are read from the schema of the connection, which includes any custom field columns
+	schema, err := p.client.GetSchema(p.connections[0])
+	if err != nil {
+		t.Fatalf("unable to obtain schema: %v", err)
+	}
+	tbl, ok := schema.Schema[table]
+	if !ok {
+		t.Fatalf("unknown table %s", table)
+	}
+
+	columns := make([]string, 0, len(tbl.Columns))
+	for _, c := range tbl.Columns {
+		columns = append(columns, c.Name)
+	}
+
+	qualMap := make(map[string]*proto.Quals)
+	for column, value := range quals {
+		var ops []op
+		switch v := value.(type) {
+		case op:
+			ops = []op{v}
+		case []op:
+			ops = v
+		default:
+			ops = []op{{"=", v}}
+		}
+
+		qualMap[column] = &proto.Quals{}
+		for _, o := range ops {
+			qualMap[column].Quals = append(qualMap[column].Quals, &proto.Qual{
+				FieldName: column,
+				Operator:  &proto.Qual_StringValue{StringValue: o.operator},
+				Value:     qualValue(t, o.value),
+			})
+		}
+	}
+
+	connectionData := make(map[string]*proto.ExecuteConnectionData, len(p.connections))
+	for _, c := range p.connections {
+		connectionData[c] = &proto.ExecuteConnectionData{}
+		if limit > 0 {
+			connectionData[c].Limit = &proto.NullableInt{Value: limit}
+		}
+	}
+
+	p.calls++
+	stream, _, cancel, err := p.client.Execute(&proto.ExecuteRequest{
+		Table:                 table,
+		QueryContext:          &proto.QueryContext{Columns: columns, Quals: qualMap},
+		Connection:            p.connection,
+		CallId:                fmt.Sprintf("%s-%d", t.Name(), p.calls),
+		ExecuteConnectionData: connectionData,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stream, cancel, nil
+}
+
+// mustQuery is query for tests which expect the query to succeed.
+func (p *testPlugin) mustQuery(t *testing.T, table string, quals map[string]interface{}, limit int64) []row {
+	t.Helper()
+
+	rows, err := p.query(t, table, quals, limit)
+	if err != nil {
+		t.Fatalf("query of %s failed: %v", table, err)
+	}
+
+	return rows
+}
+
+func qualValue(t *testing.T, value interface{}) *proto.QualValue {
+	switch v := value.(type) {
+	case int:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: int64(v)}}
+	case string:
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}}
+	case bool:
+		return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: v}}
+	case time.Time:
+		return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(v)}}
+	case []string:
+		list := &proto.QualValueList{}
+		for _, s := range v {
+			list.Values = append(list.Values, qualValue(t, s))
+		}
+		return &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}
+	case []int:
+		list := &proto.QualValueList{}
+		for _, i := range v {
+			list.Values = append(list.Values, qualValue(t, i))
+		}
+		return &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}
+	default:
+		t.Fatalf("unsupported qual value %T", value)
+		return nil
+	}
+}
+
+// columnValue converts column to a Go value, JSON columns are decoded as by encoding/json.
+func columnValue(column *proto.Column) interface{} {
+	switch v := column.Value.(type) {
+	case *proto.Column_NullValue:
+		return nil
+	case *proto.Column_IntValue:
+		return v.IntValue
+	case *proto.Column_DoubleValue:
+		return v.DoubleValue
+	case *proto.Column_StringValue:
+		return v.StringValue
+	case *proto.Column_BoolValue:
+		return v.BoolValue
+	case *proto.Column_TimestampValue:
+		return v.TimestampValue.AsTime()
+	case *proto.Column_JsonValue:
+		var out interface{}
+		if err := json.Unmarshal(v.JsonValue, &out); err != nil {
+			return string(v.JsonValue)
+		}
+		return out
+	default:
+		return column.Value
+	}
+}
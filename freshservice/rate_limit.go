@@ -0,0 +1,91 @@
+package freshservice
+
+import (
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRequestsPerMinute is the API rate limit of the lowest FreshService plan.
+	defaultRequestsPerMinute = 100
+	defaultMaxConcurrency    = 5
+)
+
+// rateLimiter throttles requests made with the same connection, keeping within the per-minute quota of the
+// FreshService plan and limiting the number of requests in flight at any point in time.
+type rateLimiter struct {
+	requestsPerMinute int
+	maxConcurrency    int
+	limiter           *rate.Limiter
+	sem               *semaphore.Weighted
+}
+
+var (
+	rateLimiters     = make(map[string]*rateLimiter)
+	rateLimitersLock sync.Mutex
+)
+
+// connectionRateLimiter returns the rateLimiter shared by all clients of the named connection, a new rateLimiter is
+// only created if none exists yet or the connection has been reconfigured. A requests_per_minute of 0 disables
+// throttling.
+func connectionRateLimiter(connection string, config PluginConfig) *rateLimiter {
+	rpm := defaultRequestsPerMinute
+	if config.RequestsPerMinute != nil && *config.RequestsPerMinute >= 0 {
+		rpm = *config.RequestsPerMinute
+	}
+
+	concurrency := defaultMaxConcurrency
+	if config.MaxConcurrency != nil && *config.MaxConcurrency > 0 {
+		concurrency = *config.MaxConcurrency
+	}
+
+	rateLimitersLock.Lock()
+	defer rateLimitersLock.Unlock()
+
+	if rl, ok := rateLimiters[connection]; ok && rl.requestsPerMinute == rpm && rl.maxConcurrency == concurrency {
+		return rl
+	}
+
+	limit := rate.Inf
+	if rpm > 0 {
+		limit = rate.Every(time.Minute / time.Duration(rpm))
+	}
+
+	rl := &rateLimiter{
+		requestsPerMinute: rpm,
+		maxConcurrency:    concurrency,
+		limiter:           rate.NewLimiter(limit, 1),
+		sem:               semaphore.NewWeighted(int64(concurrency)),
+	}
+	rateLimiters[connection] = rl
+
+	return rl
+}
+
+// transport wraps base so that every request (including retries) waits for the rateLimiter before being sent.
+func (rl *rateLimiter) transport(base http.RoundTripper) http.RoundTripper {
+	return &rateLimitedTransport{base: base, rl: rl}
+}
+
+type rateLimitedTransport struct {
+	base http.RoundTripper
+	rl   *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.rl.sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	defer t.rl.sem.Release(1)
+
+	if err := t.rl.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
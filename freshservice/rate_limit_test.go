@@ -0,0 +1,25 @@
+package freshservice
+
+import (
+	"golang.org/x/time/rate"
+	"testing"
+	"time"
+)
+
+func TestConnectionRateLimiter(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PluginConfig
+		want   rate.Limit
+	}{
+		{name: "default", config: PluginConfig{}, want: rate.Every(time.Minute / defaultRequestsPerMinute)},
+		{name: "configured", config: PluginConfig{RequestsPerMinute: ptr(500)}, want: rate.Every(time.Minute / 500)},
+		{name: "disabled", config: PluginConfig{RequestsPerMinute: ptr(0)}, want: rate.Inf},
+	}
+
+	for _, tt := range tests {
+		if got := connectionRateLimiter(t.Name()+tt.name, tt.config).limiter.Limit(); got != tt.want {
+			t.Errorf("%s: expected a limit of %v requests per second, got %v", tt.name, tt.want, got)
+		}
+	}
+}
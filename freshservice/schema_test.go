@@ -0,0 +1,188 @@
+package freshservice
+
+import (
+	"context"
+	"github.com/iancoleman/strcase"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tableItems holds the type of the items streamed by the List (and returned by the Get) hydrate of every table.
+var tableItems = map[string]interface{}{
+	"freshservice_agent":                 fs.Agent{},
+	"freshservice_agent_role":            fs.AgentRole{},
+	"freshservice_announcement":          announcementItem{},
+	"freshservice_api_usage":             usageRow{},
+	"freshservice_asset":                 assetItem{},
+	"freshservice_asset_component":       fs.AssetComponent{},
+	"freshservice_asset_contract":        fs.AssetContract{},
+	"freshservice_asset_type":            fs.AssetType{},
+	"freshservice_business_hour":         fs.BusinessHour{},
+	"freshservice_change":                changeItem{},
+	"freshservice_change_note":           fs.Note{},
+	"freshservice_contract":              fs.Contract{},
+	"freshservice_contract_type":         fs.ContractType{},
+	"freshservice_department":            fs.Department{},
+	"freshservice_location":              fs.Location{},
+	"freshservice_problem":               problemItem{},
+	"freshservice_problem_note":          fs.Note{},
+	"freshservice_problem_task":          fs.Task{},
+	"freshservice_problem_timeentry":     fs.TimeEntry{},
+	"freshservice_product":               fs.Product{},
+	"freshservice_purchase_order":        fs.PurchaseOrder{},
+	"freshservice_release":               releaseItem{},
+	"freshservice_release_note":          fs.Note{},
+	"freshservice_release_task":          fs.Task{},
+	"freshservice_release_timeentry":     fs.TimeEntry{},
+	"freshservice_requester":             fs.Requester{},
+	"freshservice_service":               serviceItem{},
+	"freshservice_sla_policy":            fs.Policy{},
+	"freshservice_software":              fs.Application{},
+	"freshservice_software_installation": fs.SoftwareInstallation{},
+	"freshservice_software_user":         fs.SoftwareUser{},
+	"freshservice_solution_article":      fs.SolutionArticle{},
+	"freshservice_solution_category":     solutionCategoryItem{},
+	"freshservice_solution_folder":       fs.SolutionFolder{},
+	"freshservice_ticket":                ticketItem{},
+	"freshservice_ticket_conversation":   fs.Conversation{},
+	"freshservice_ticket_task":           fs.Task{},
+	"freshservice_ticket_timeentry":      fs.TimeEntry{},
+	"freshservice_vendor":                fs.Vendor{},
+	"freshservice_workspace":             workspace{},
+}
+
+// TestColumnsMapToFields checks every column can be populated, a column whose name (or FromField path) does not
+// match a field of the hydrated item silently returns null.
+func TestColumnsMapToFields(t *testing.T) {
+	p := Plugin(context.Background())
+
+	for name, table := range tableMap() {
+		item, ok := tableItems[name]
+		if !ok {
+			t.Errorf("table %s has no item type in tableItems", name)
+			continue
+		}
+
+		for _, column := range table.Columns {
+			if err := checkColumn(p, table, column, reflect.TypeOf(item)); err != "" {
+				t.Errorf("%s.%s %s", name, column.Name, err)
+			}
+		}
+	}
+}
+
+// checkColumn returns why the column can never be populated from an item of type itemType, or "" if it can be.
+func checkColumn(p *plugin.Plugin, table *plugin.Table, column *plugin.Column, itemType reflect.Type) string {
+	transforms := column.Transform
+	if transforms == nil {
+		transforms = table.DefaultTransform
+	}
+	if transforms == nil {
+		transforms = p.DefaultTransform
+	}
+	if transforms == nil || len(transforms.Transforms) == 0 {
+		return "has no transform"
+	}
+
+	call := transforms.Transforms[0]
+	switch {
+	case sameFunc(call.Transform, transform.FieldValueGo):
+		path := helpers.LintName(strcase.ToCamel(column.Name))
+		if !hasField(itemType, path) {
+			return "maps to field " + path + " which does not exist on " + itemType.Name()
+		}
+	case sameFunc(call.Transform, transform.FieldValue):
+		paths, _ := call.Param.([]string)
+		for _, path := range paths {
+			if hasField(itemType, path) {
+				return ""
+			}
+		}
+		return "maps to field(s) " + strings.Join(paths, ", ") + " which do not exist on " + itemType.Name()
+	case sameFunc(call.Transform, transform.QualValue):
+		qual, _ := call.Param.(string)
+		if !isKeyColumn(table, qual) {
+			return "is populated from qual " + qual + " which is not a key column"
+		}
+	}
+
+	return ""
+}
+
+func sameFunc(a transform.TransformFunc, b transform.TransformFunc) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+// hasField reports whether the dot separated path resolves to a field of t, paths into maps & interfaces can not be
+// checked and are assumed to exist.
+func hasField(t reflect.Type, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Map, reflect.Interface:
+			return true
+		case reflect.Struct:
+			f, ok := t.FieldByName(name)
+			if !ok || !f.IsExported() {
+				return false
+			}
+			t = f.Type
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isKeyColumn(table *plugin.Table, name string) bool {
+	var keyColumns []*plugin.KeyColumn
+	if table.List != nil {
+		keyColumns = append(keyColumns, table.List.KeyColumns...)
+	}
+	if table.Get != nil {
+		keyColumns = append(keyColumns, table.Get.KeyColumns...)
+	}
+
+	for _, k := range keyColumns {
+		if k.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TestColumnHydratesDeclared checks every column hydrate is declared in the HydrateConfig of its table, the SDK panics
+// when an undeclared hydrate function returns an error.
+func TestColumnHydratesDeclared(t *testing.T) {
+	tables := tableMap()
+	tables["asset_type"] = tableAssetOfType("asset_type", fs.AssetType{ID: 1, Name: "Laptop"}, nil)
+	tables["custom_object"] = tableCustomObject("custom_object", customObject{ID: 1, Title: "Owners"})
+
+	for name, table := range tables {
+		for _, column := range table.Columns {
+			if column.Hydrate == nil {
+				continue
+			}
+
+			declared := false
+			for _, config := range table.HydrateConfig {
+				if reflect.ValueOf(config.Func).Pointer() == reflect.ValueOf(column.Hydrate).Pointer() {
+					declared = true
+				}
+			}
+			if !declared {
+				t.Errorf("%s.%s is hydrated by a function which is not declared in HydrateConfig", name, column.Name)
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tableAgent() *plugin.Table {
@@ -134,6 +135,7 @@ func agentColumns() []*plugin.Column {
 			Name:        "department_ids",
 			Description: "Array of Unique IDs of the departments associated with the agent",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("DepartmentIDs"),
 		},
 		{
 			Name:        "last_login_at",
@@ -173,10 +175,11 @@ func getAgent(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	agent, _, err := client.Agents.GetAgent(id)
+	agent := new(fs.Agent)
+	err = client.get(ctx, fmt.Sprintf("agents/%d", id), "agent", agent)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_agent.getAgent", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain agent with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain agent with id %d: %w", id, err)
 	}
 
 	return agent, nil
@@ -190,19 +193,7 @@ func listAgents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListAgentsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListAgentsOptions{}
 
 	q := d.EqualsQuals
 
@@ -216,22 +207,12 @@ func listAgents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		filter.Active = &a
 	}
 
-	for {
-		agents, res, err := client.Agents.ListAgents(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_agent.listAgents", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain agents: %v", err)
-		}
-
-		for _, agent := range agents.Collection {
-			d.StreamListItem(ctx, agent)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "agents", &filter, &filter.ListOptions, func(agents *fs.Agents) []fs.Agent {
+		return agents.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_agent.listAgents", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain agents: %w", err)
 	}
 	return nil, nil
 }
@@ -67,10 +67,11 @@ func getAgentRole(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	role, _, err := client.Agents.GetAgentRole(id)
+	role := new(fs.AgentRole)
+	err = client.get(ctx, fmt.Sprintf("roles/%d", id), "role", role)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_agent_role.getAgentRole", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain agent role with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain agent role with id %d: %w", id, err)
 	}
 
 	return role, nil
@@ -83,36 +84,14 @@ func listAgentRoles(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListAgentRolesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListAgentRolesOptions{}
 
-	for {
-		roles, res, err := client.Agents.ListAgentRoles(filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_agent_role.listAgentRoles", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain agent roles: %v", err)
-		}
-
-		for _, role := range roles.Collection {
-			d.StreamListItem(ctx, role)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "roles", &filter, &filter.ListOptions, func(roles *fs.AgentRoles) []fs.AgentRole {
+		return roles.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_agent_role.listAgentRoles", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain agent roles: %w", err)
 	}
 
 	return nil, nil
@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tableAnnouncement() *plugin.Table {
@@ -19,6 +20,7 @@ func tableAnnouncement() *plugin.Table {
 					Name:    "state",
 					Require: plugin.Optional,
 				},
+				workspaceIdKeyColumn(),
 			},
 		},
 		Get: &plugin.GetConfig{
@@ -60,6 +62,7 @@ func announcementColumns() []*plugin.Column {
 			Name:        "body_html",
 			Description: "Body of the announcement in HTML format.",
 			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("BodyHtml"),
 		},
 		{
 			Name:        "visible_from",
@@ -111,6 +114,7 @@ func announcementColumns() []*plugin.Column {
 			Description: "Timestamp when the announcement was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -124,10 +128,11 @@ func getAnnouncement(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	announcement, _, err := client.Announcements.GetAnnouncement(id)
+	announcement := new(announcementItem)
+	err = client.get(ctx, fmt.Sprintf("announcements/%d", id), "announcement", announcement)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_announcement.getAnnouncement", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain announcement with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain announcement with id %d: %w", id, err)
 	}
 
 	return announcement, nil
@@ -140,41 +145,25 @@ func listAnnouncements(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListAnnouncementsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := struct {
+		fs.ListAnnouncementsOptions
+		workspaceOptions
+	}{}
 
 	q := d.EqualsQuals
 	if q["state"] != nil {
 		filter.State = q["state"].GetStringValue()
 	}
 
-	for {
-		announcements, res, err := client.Announcements.ListAnnouncements(filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_announcement.listAnnouncements", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain announcements: %v", err)
-		}
-
-		for _, announcement := range announcements.Collection {
-			d.StreamListItem(ctx, announcement)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, "announcements", &filter, &filter.ListOptions, func(announcements *announcementItems) []announcementItem {
+			return announcements.Collection
+		})
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_announcement.listAnnouncements", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain announcements: %w", err)
 	}
 	return nil, nil
 }
@@ -0,0 +1,107 @@
+package freshservice
+
+import (
+	"context"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableApiUsage() *plugin.Table {
+	return &plugin.Table{
+		Name:        "freshservice_api_usage",
+		Description: "Obtain the requests made to the FreshService API by this connection (since Steampipe was started) and the remaining API quota of the account.",
+		List: &plugin.ListConfig{
+			Hydrate: listApiUsage,
+		},
+		// the usage changes with every query, so must never be served from the cache
+		Cache: &plugin.TableCacheOptions{
+			Enabled: false,
+		},
+		Columns: apiUsageColumns(),
+	}
+}
+
+func apiUsageColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "endpoint",
+			Description: "Path of the API endpoint, relative to /api/v2/ and with ids replaced by {id} (for example tickets/{id}/tasks).",
+			Type:        proto.ColumnType_STRING,
+		},
+		{
+			Name:        "requests",
+			Description: "Number of requests sent to the endpoint, including retries.",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "retries",
+			Description: "Number of requests to the endpoint which were retries of a failed (or rate limited) request.",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "throttled",
+			Description: "Number of requests to the endpoint which were rejected as the rate limit was exceeded (HTTP 429).",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "errors",
+			Description: "Number of requests to the endpoint which failed, other than those which were rate limited.",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "average_latency_ms",
+			Description: "Average time in milliseconds taken by a request to the endpoint.",
+			Type:        proto.ColumnType_DOUBLE,
+		},
+		{
+			Name:        "max_latency_ms",
+			Description: "Longest time in milliseconds taken by a request to the endpoint.",
+			Type:        proto.ColumnType_DOUBLE,
+		},
+		{
+			Name:        "last_status",
+			Description: "HTTP status of the last response from the endpoint, 0 if no response was received.",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "last_request_at",
+			Description: "Timestamp of the last request to the endpoint.",
+			Type:        proto.ColumnType_TIMESTAMP,
+		},
+		{
+			Name:        "rate_limit_total",
+			Description: "Number of requests per minute allowed for the account, as last reported by FreshService (X-Ratelimit-Total).",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "rate_limit_remaining",
+			Description: "Number of requests remaining in the current minute for the account, as last reported by FreshService (X-Ratelimit-Remaining).",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "rate_limit_used_current_request",
+			Description: "Number of requests counted against the rate limit for the last request (X-Ratelimit-Used-CurrentRequest).",
+			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("RateLimitUsed"),
+		},
+		{
+			Name:        "rate_limit_updated_at",
+			Description: "Timestamp when the rate limit was last reported by FreshService.",
+			Type:        proto.ColumnType_TIMESTAMP,
+		},
+	}
+}
+
+// Hydrate Functions
+func listApiUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	for _, r := range connectionUsage(d.Connection.Name).rows() {
+		d.StreamListItem(ctx, r)
+
+		if d.RowsRemaining(ctx) <= 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
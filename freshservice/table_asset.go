@@ -3,7 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
-	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
@@ -14,6 +14,9 @@ func tableAsset() *plugin.Table {
 		Description: "Obtain information about Assets stored within the FreshService instance.",
 		List: &plugin.ListConfig{
 			Hydrate: listAssets,
+			KeyColumns: []*plugin.KeyColumn{
+				workspaceIdKeyColumn(),
+			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getAsset,
@@ -110,6 +113,12 @@ func assetColumns() []*plugin.Column {
 			Description: "Timestamp when the asset was updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		{
+			Name:        "type_fields",
+			Description: "Values of the fields specific to the asset type (and its parent types), keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -123,10 +132,11 @@ func getAsset(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	asset, _, err := client.Assets.GetAsset(id)
+	asset := new(assetItem)
+	err = client.getWith(ctx, fmt.Sprintf("assets/%d", id), &assetOptions{Include: assetInclude(d)}, "asset", asset)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_asset.getAsset", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain asset with display_id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain asset with display_id %d: %w", id, err)
 	}
 
 	return asset, nil
@@ -139,37 +149,31 @@ func listAssets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListAssetsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
+	err = streamAssets(ctx, d, client, assetOptions{Include: assetInclude(d)})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_asset.listAssets", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain assets: %w", err)
 	}
 
-	for {
-		agents, res, err := client.Assets.ListAssets(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_asset.listAssets", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain assets: %v", err)
-		}
-
-		for _, agent := range agents.Collection {
-			d.StreamListItem(ctx, agent)
-		}
+	return nil, nil
+}
 
-		if res.Header.Get("link") == "" {
-			break
-		}
+// streamAssets streams the assets of every workspace the query covers, filtered by filter.
+func streamAssets(ctx context.Context, d *plugin.QueryData, client *apiClient, filter assetOptions) error {
+	return forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, "assets", &filter, &filter.ListOptions, func(assets *assetItems) []assetItem {
+			return assets.Collection
+		})
+	})
+}
 
-		filter.Page += 1
+// assetInclude returns the include parameter for a query of assets, type_fields are only requested when required as
+// they are costly for FreshService to obtain.
+func assetInclude(d *plugin.QueryData) string {
+	if helpers.StringSliceContains(d.QueryContext.Columns, "type_fields") {
+		return "type_fields"
 	}
 
-	return nil, nil
+	return ""
 }
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -73,10 +74,11 @@ func listAssetComponents(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	components, _, err := client.Assets.ListAssetComponents(displayId)
+	components := new(fs.AssetComponents)
+	_, err = client.list(ctx, fmt.Sprintf("assets/%d/components", displayId), nil, components)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_asset_component.listAssetComponents", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain asset components: %v", err)
+		return nil, fmt.Errorf("unable to obtain asset components: %w", err)
 	}
 
 	for _, component := range components.Collection {
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -73,10 +74,11 @@ func listAssetContracts(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	contracts, _, err := client.Assets.ListAssetContracts(displayId)
+	contracts := new(fs.AssetContracts)
+	_, err = client.list(ctx, fmt.Sprintf("assets/%d/contracts", displayId), nil, contracts)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_asset_contract.listAssetContracts", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain asset contracts: %v", err)
+		return nil, fmt.Errorf("unable to obtain asset contracts: %w", err)
 	}
 
 	for _, contract := range contracts.Collection {
@@ -73,10 +73,11 @@ func getAssetType(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	assetType, _, err := client.Assets.GetAssetType(id)
+	assetType := new(fs.AssetType)
+	err = client.get(ctx, fmt.Sprintf("asset_types/%d", id), "asset_type", assetType)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_asset_type.getAssetType", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain asset type with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain asset type with id %d: %w", id, err)
 	}
 
 	return assetType, nil
@@ -89,36 +90,14 @@ func listAssetTypes(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListAssetTypesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListAssetTypesOptions{}
 
-	for {
-		assetTypes, res, err := client.Assets.ListAssetTypes(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_asset_type.listAssetTypes", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain asset types: %v", err)
-		}
-
-		for _, assetType := range assetTypes.Collection {
-			d.StreamListItem(ctx, assetType)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "asset_types", &filter, &filter.ListOptions, func(assetTypes *fs.AssetTypes) []fs.AssetType {
+		return assetTypes.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_asset_type.listAssetTypes", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain asset types: %w", err)
 	}
 	return nil, nil
 }
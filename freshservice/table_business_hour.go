@@ -83,10 +83,11 @@ func getBusinessHours(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	bh, _, err := client.BusinessHours.GetBusinessHours(id)
+	bh := new(fs.BusinessHour)
+	err = client.get(ctx, fmt.Sprintf("business_hours/%d", id), "business_hours", bh)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_business_hour.getBusinessHours", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain business hours configuration with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain business hours configuration with id %d: %w", id, err)
 	}
 
 	return bh, nil
@@ -99,36 +100,14 @@ func listBusinessHours(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListBusinessHoursOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListBusinessHoursOptions{}
 
-	for {
-		bhs, res, err := client.BusinessHours.ListBusinessHours(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_business_hour.listBusinessHours", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain business hours configurations: %v", err)
-		}
-
-		for _, bh := range bhs.Collection {
-			d.StreamListItem(ctx, bh)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "business_hours", &filter, &filter.ListOptions, func(bhs *fs.BusinessHours) []fs.BusinessHour {
+		return bhs.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_business_hour.listBusinessHours", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain business hours configurations: %w", err)
 	}
 
 	return nil, nil
@@ -20,13 +20,16 @@ func tableChange() *plugin.Table {
 					Name:    "requester_id",
 					Require: plugin.Optional,
 				},
+				updatedAtKeyColumn(),
+				workspaceIdKeyColumn(),
 			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getChange,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: changeColumns(),
+		HydrateConfig: formLabelsHydrateConfig(),
+		Columns:       changeColumns(),
 	}
 }
 
@@ -76,7 +79,8 @@ func changeColumns() []*plugin.Column {
 			Name:        "priority_desc",
 			Description: "Description of the change priority",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Priority").Transform(changePriorityDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, changePriority),
 		},
 		{
 			Name:        "status",
@@ -87,7 +91,8 @@ func changeColumns() []*plugin.Column {
 			Name:        "status_desc",
 			Description: "Description of the change status.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Status").Transform(changeStatusDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, changeStatus),
 		},
 		{
 			Name:        "impact",
@@ -98,7 +103,8 @@ func changeColumns() []*plugin.Column {
 			Name:        "impact_desc",
 			Description: "Description of the change impact.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Impact").Transform(changeImpactDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, changeImpact),
 		},
 		{
 			Name:        "risk",
@@ -109,18 +115,21 @@ func changeColumns() []*plugin.Column {
 			Name:        "risk_desc",
 			Description: "Description of the change risk.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Risk").Transform(changeRiskDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, changeRisk),
 		},
 		{
 			Name:        "type",
 			Description: "Type of the change.",
 			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("ChangeType"),
 		},
 		{
 			Name:        "type_desc",
 			Description: "Description of the change type.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("ChangeType").Transform(changeTypeDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, changeType),
 		},
 		{
 			Name:        "approval_status",
@@ -167,6 +176,12 @@ func changeColumns() []*plugin.Column {
 			Description: "Timestamp at which the change was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		{
+			Name:        "custom_fields",
+			Description: "Values of the custom fields of the change, keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -180,10 +195,11 @@ func getChange(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	change, _, err := client.Changes.GetChange(id)
+	change := new(changeItem)
+	err = client.get(ctx, fmt.Sprintf("changes/%d", id), "change", change)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_change.getChange", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain change with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain change with id %d: %w", id, err)
 	}
 
 	return change, nil
@@ -197,145 +213,47 @@ func listChanges(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	}
 
 	q := d.EqualsQuals
-	filter := fs.ListChangesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := struct {
+		fs.ListChangesOptions
+		workspaceOptions
+	}{}
 
 	if q["requester_id"] != nil {
 		r := int(q["requester_id"].GetInt64Value())
 		filter.RequesterID = &r
 	}
 
-	for {
-		changes, res, err := client.Changes.ListChanges(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_change.listChanges", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain changes: %v", err)
-		}
-
-		for _, change := range changes.Collection {
-			d.StreamListItem(ctx, change)
-		}
+	filter.UpdatedSince = updatedSince(d)
 
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, "changes", &filter, &filter.ListOptions, func(changes *changeItems) []changeItem {
+			return changes.Collection
+		})
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_change.listChanges", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain changes: %w", err)
 	}
 
 	return nil, nil
 }
 
-// Transform Functions
-func changePriorityDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	case 4:
-		return "Urgent", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func changeStatusDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Open", nil
-	case 2:
-		return "Planning", nil
-	case 3:
-		return "Approval", nil
-	case 4:
-		return "Pending Release", nil
-	case 5:
-		return "Pending Review", nil
-	case 6:
-		return "Closed", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func changeTypeDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Minor", nil
-	case 2:
-		return "Standard", nil
-	case 3:
-		return "Major", nil
-	case 4:
-		return "Emergency", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func changeImpactDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func changeRiskDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	case 4:
-		return "Very High", nil
-	default:
-		return "Unknown", nil
-	}
-}
+// Labels of the change fields which have a *_desc column, the defaults are used when the change form can't be read.
+var (
+	changePriority = formLabelField{Name: "priority", Field: "Priority", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High", 4: "Urgent",
+	}}
+	changeStatus = formLabelField{Name: "status", Field: "Status", Defaults: map[int]string{
+		1: "Open", 2: "Planning", 3: "Approval", 4: "Pending Release", 5: "Pending Review", 6: "Closed",
+	}}
+	changeType = formLabelField{Name: "change_type", Field: "ChangeType", Defaults: map[int]string{
+		1: "Minor", 2: "Standard", 3: "Major", 4: "Emergency",
+	}}
+	changeImpact = formLabelField{Name: "impact", Field: "Impact", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High",
+	}}
+	changeRisk = formLabelField{Name: "risk", Field: "Risk", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High", 4: "Very High",
+	}}
+)
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -81,10 +82,11 @@ func listChangeNotes(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	notes, _, err := client.Changes.ListChangeNotes(changeId)
+	notes := new(fs.Notes)
+	_, err = client.list(ctx, fmt.Sprintf("changes/%d/notes", changeId), nil, notes)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_change_note.listChangeNotes", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain change notes: %v", err)
+		return nil, fmt.Errorf("unable to obtain change notes: %w", err)
 	}
 
 	for _, note := range notes.Collection {
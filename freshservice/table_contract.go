@@ -138,7 +138,7 @@ func contractColumns() []*plugin.Column {
 
 // Hydrate Functions
 func getContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	id := int(d.EqualsQuals["display_id"].GetInt64Value())
+	id := int(d.EqualsQuals["id"].GetInt64Value())
 
 	client, err := connect(ctx, d)
 	if err != nil {
@@ -146,10 +146,11 @@ func getContract(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	contract, _, err := client.Contracts.GetContract(id)
+	contract := new(fs.Contract)
+	err = client.get(ctx, fmt.Sprintf("contracts/%d", id), "contract", contract)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_contract.getContract", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain contract with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain contract with id %d: %w", id, err)
 	}
 
 	return contract, nil
@@ -162,36 +163,14 @@ func listContracts(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListContractsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListContractsOptions{}
 
-	for {
-		contracts, res, err := client.Contracts.ListContracts(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_contract.listContracts", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain contracts: %v", err)
-		}
-
-		for _, contract := range contracts.Collection {
-			d.StreamListItem(ctx, contract)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "contracts", &filter, &filter.ListOptions, func(contracts *fs.Contracts) []fs.Contract {
+		return contracts.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_contract.listContracts", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain contracts: %w", err)
 	}
 
 	return nil, nil
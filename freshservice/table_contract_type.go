@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
@@ -66,10 +67,11 @@ func listContractTypes(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	contractTypes, _, err := client.Contracts.ListContractTypes()
+	contractTypes := new(fs.ContractTypes)
+	_, err = client.list(ctx, "contract_types", nil, contractTypes)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_contract_type.listContractTypes", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain contract types: %v", err)
+		return nil, fmt.Errorf("unable to obtain contract types: %w", err)
 	}
 
 	for _, contractType := range contractTypes.Collection {
@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tableDepartment() *plugin.Table {
@@ -44,11 +45,13 @@ func departmentColumns() []*plugin.Column {
 			Name:        "head_user_id",
 			Description: "User ID of the agent or requester who serves as the head of the department.",
 			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("HeadUserId"),
 		},
 		{
 			Name:        "prime_user_id",
 			Description: "User ID of the agent or requester who serves as the prime user of the department.",
 			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("PrimeUserId"),
 		},
 		{
 			Name:        "domains",
@@ -78,10 +81,11 @@ func getDepartment(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	department, _, err := client.Departments.GetDepartment(id)
+	department := new(fs.Department)
+	err = client.get(ctx, fmt.Sprintf("departments/%d", id), "department", department)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_department.getDepartment", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain department with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain department with id %d: %w", id, err)
 	}
 
 	return department, nil
@@ -94,36 +98,14 @@ func listDepartments(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListDepartmentsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListDepartmentsOptions{}
 
-	for {
-		departments, res, err := client.Departments.ListDepartments(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_department.listDepartments", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain departments: %v", err)
-		}
-
-		for _, department := range departments.Collection {
-			d.StreamListItem(ctx, department)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "departments", &filter, &filter.ListOptions, func(departments *fs.Departments) []fs.Department {
+		return departments.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_department.listDepartments", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain departments: %w", err)
 	}
 
 	return nil, nil
@@ -105,10 +105,11 @@ func getLocation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	location, _, err := client.Locations.GetLocation(id)
+	location := new(fs.Location)
+	err = client.get(ctx, fmt.Sprintf("locations/%d", id), "location", location)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_location.getLocation", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain location with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain location with id %d: %w", id, err)
 	}
 
 	return location, nil
@@ -121,36 +122,14 @@ func listLocations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListLocationsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListLocationsOptions{}
 
-	for {
-		locations, res, err := client.Locations.ListLocations(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_location.listLocations", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain asset types: %v", err)
-		}
-
-		for _, location := range locations.Collection {
-			d.StreamListItem(ctx, location)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "locations", &filter, &filter.ListOptions, func(locations *fs.Locations) []fs.Location {
+		return locations.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_location.listLocations", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain asset types: %w", err)
 	}
 	return nil, nil
 }
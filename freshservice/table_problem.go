@@ -15,12 +15,17 @@ func tableProblem() *plugin.Table {
 		Description: "Obtain information on Problems raised in the FreshService instance.",
 		List: &plugin.ListConfig{
 			Hydrate: listProblems,
+			KeyColumns: []*plugin.KeyColumn{
+				updatedAtKeyColumn(),
+				workspaceIdKeyColumn(),
+			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getProblem,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: problemColumns(),
+		HydrateConfig: formLabelsHydrateConfig(),
+		Columns:       problemColumns(),
 	}
 }
 
@@ -65,7 +70,8 @@ func problemColumns() []*plugin.Column {
 			Name:        "priority_desc",
 			Description: "Description of the problems priority",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Priority").Transform(problemPriorityDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, problemPriority),
 		},
 		{
 			Name:        "status",
@@ -76,7 +82,8 @@ func problemColumns() []*plugin.Column {
 			Name:        "status_desc",
 			Description: "Description of the problems status.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Status").Transform(problemStatusDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, problemStatus),
 		},
 		{
 			Name:        "impact",
@@ -87,7 +94,8 @@ func problemColumns() []*plugin.Column {
 			Name:        "impact_desc",
 			Description: "Description of the problems impact.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Impact").Transform(problemImpactDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, problemImpact),
 		},
 		{
 			Name:        "known_error",
@@ -157,6 +165,12 @@ func problemColumns() []*plugin.Column {
 			Description: "Timestamp at which the problem was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		{
+			Name:        "custom_fields",
+			Description: "Values of the custom fields of the problem, keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -170,10 +184,11 @@ func getProblem(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	problem, _, err := client.Problems.GetProblem(id)
+	problem := new(problemItem)
+	err = client.get(ctx, fmt.Sprintf("problems/%d", id), "problem", problem)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_problem.getProblem", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain problem with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain problem with id %d: %w", id, err)
 	}
 
 	return problem, nil
@@ -186,94 +201,37 @@ func listProblems(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListProblemsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
+	filter := struct {
+		fs.ListProblemsOptions
+		workspaceOptions
+		updatedSinceOptions
+	}{
+		updatedSinceOptions: updatedSinceOptions{UpdatedSince: updatedSince(d)},
 	}
 
-	for {
-		problems, res, err := client.Problems.ListProblems(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_problem.listProblems", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain releases: %v", err)
-		}
-
-		for _, problem := range problems.Collection {
-			d.StreamListItem(ctx, problem)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, "problems", &filter, &filter.ListOptions, func(problems *problemItems) []problemItem {
+			return problems.Collection
+		})
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_problem.listProblems", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain releases: %w", err)
 	}
 
 	return nil, nil
 }
 
-// Transform Functions
-func problemPriorityDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	case 4:
-		return "Urgent", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func problemStatusDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Open", nil
-	case 2:
-		return "Change Requested", nil
-	case 3:
-		return "Closed", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func problemImpactDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	default:
-		return "Unknown", nil
-	}
-}
+// Labels of the problem fields which have a *_desc column, the defaults are used when the problem form can't be read.
+var (
+	problemPriority = formLabelField{Name: "priority", Field: "Priority", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High", 4: "Urgent",
+	}}
+	problemStatus = formLabelField{Name: "status", Field: "Status", Defaults: map[int]string{
+		1: "Open", 2: "Change Requested", 3: "Closed",
+	}}
+	problemImpact = formLabelField{Name: "impact", Field: "Impact", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High",
+	}}
+)
@@ -108,36 +108,14 @@ func listProblemTasks(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListTasksOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListTasksOptions{}
 
-	for {
-		tasks, res, err := client.Problems.ListTasks(problemId, &filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_problem_task.listProblemTasks", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain tasks: %v", err)
-		}
-
-		for _, task := range tasks.Collection {
-			d.StreamListItem(ctx, task)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, fmt.Sprintf("problems/%d/tasks", problemId), &filter, &filter.ListOptions, func(tasks *fs.Tasks) []fs.Task {
+		return tasks.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_problem_task.listProblemTasks", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain tasks: %w", err)
 	}
 
 	return nil, nil
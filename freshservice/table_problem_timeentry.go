@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -101,10 +102,11 @@ func listProblemTimeEntries(ctx context.Context, d *plugin.QueryData, h *plugin.
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	entries, _, err := client.Problems.ListTimeEntries(problemId)
+	entries := new(fs.TimeEntries)
+	_, err = client.list(ctx, fmt.Sprintf("problems/%d/time_entries", problemId), nil, entries)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_problem_timeentry.listProblemTimeEntries", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain time entries: %v", err)
+		return nil, fmt.Errorf("unable to obtain time entries: %w", err)
 	}
 
 	for _, entry := range entries.Collection {
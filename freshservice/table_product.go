@@ -93,10 +93,11 @@ func getProduct(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	product, _, err := client.Products.GetProduct(id)
+	product := new(fs.Product)
+	err = client.get(ctx, fmt.Sprintf("products/%d", id), "product", product)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_product.getProduct", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain product with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain product with id %d: %w", id, err)
 	}
 
 	return product, nil
@@ -109,36 +110,14 @@ func listProducts(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListProductsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListProductsOptions{}
 
-	for {
-		products, res, err := client.Products.ListProducts(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_product.listProducts", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain products: %v", err)
-		}
-
-		for _, product := range products.Collection {
-			d.StreamListItem(ctx, product)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "products", &filter, &filter.ListOptions, func(products *fs.Products) []fs.Product {
+		return products.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_product.listProducts", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain products: %w", err)
 	}
 
 	return nil, nil
@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tablePurchaseOrder() *plugin.Table {
@@ -39,6 +40,7 @@ func purchaseOrderColumns() []*plugin.Column {
 			Name:        "po_number",
 			Description: "Unique purchase order number.",
 			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("PurchaseOrderNumber"),
 		},
 		{
 			Name:        "vendor_id",
@@ -138,10 +140,11 @@ func getPurchaseOrder(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	purchaseOrder, _, err := client.PurchaseOrders.GetPurchaseOrder(id)
+	purchaseOrder := new(fs.PurchaseOrder)
+	err = client.get(ctx, fmt.Sprintf("purchase_orders/%d", id), "purchase_order", purchaseOrder)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_purchase_order.getPurchaseOrder", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain purchase order with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain purchase order with id %d: %w", id, err)
 	}
 
 	return purchaseOrder, nil
@@ -154,36 +157,14 @@ func listPurchaseOrders(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListPurchaseOrdersOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListPurchaseOrdersOptions{}
 
-	for {
-		purchaseOrders, res, err := client.PurchaseOrders.ListPurchaseOrders(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_purchase_order.listPurchaseOrders", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain purchase orders: %v", err)
-		}
-
-		for _, purchaseOrder := range purchaseOrders.Collection {
-			d.StreamListItem(ctx, purchaseOrder)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "purchase_orders", &filter, &filter.ListOptions, func(purchaseOrders *fs.PurchaseOrders) []fs.PurchaseOrder {
+		return purchaseOrders.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_purchase_order.listPurchaseOrders", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain purchase orders: %w", err)
 	}
 
 	return nil, nil
@@ -15,12 +15,17 @@ func tableRelease() *plugin.Table {
 		Description: "Obtain information on Releases within the FreshService instance.",
 		List: &plugin.ListConfig{
 			Hydrate: listReleases,
+			KeyColumns: []*plugin.KeyColumn{
+				updatedAtKeyColumn(),
+				workspaceIdKeyColumn(),
+			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getRelease,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: releaseColumns(),
+		HydrateConfig: formLabelsHydrateConfig(),
+		Columns:       releaseColumns(),
 	}
 }
 
@@ -50,7 +55,8 @@ func releaseColumns() []*plugin.Column {
 			Name:        "priority_desc",
 			Description: "Description of the release priority.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Priority").Transform(releasePriorityDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, releasePriority),
 		},
 		{
 			Name:        "status",
@@ -61,7 +67,8 @@ func releaseColumns() []*plugin.Column {
 			Name:        "status_desc",
 			Description: "Description of the release status.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Status").Transform(releaseStatusDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, releaseStatus),
 		},
 		{
 			Name:        "release_type",
@@ -71,8 +78,9 @@ func releaseColumns() []*plugin.Column {
 		{
 			Name:        "release_type_desc",
 			Description: "Description of the release type.",
-			Type:        proto.ColumnType_INT,
-			Transform:   transform.FromField("Status").Transform(releaseTypeDesc),
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, releaseType),
 		},
 		{
 			Name:        "subject",
@@ -144,6 +152,12 @@ func releaseColumns() []*plugin.Column {
 			Description: "Timestamp at which the release was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		{
+			Name:        "custom_fields",
+			Description: "Values of the custom fields of the release, keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -157,10 +171,11 @@ func getRelease(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	release, _, err := client.Releases.GetRelease(id)
+	release := new(releaseItem)
+	err = client.get(ctx, fmt.Sprintf("releases/%d", id), "release", release)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_release.getRelease", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain release with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain release with id %d: %w", id, err)
 	}
 
 	return release, nil
@@ -173,100 +188,37 @@ func listReleases(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListReleasesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
+	filter := struct {
+		fs.ListReleasesOptions
+		workspaceOptions
+		updatedSinceOptions
+	}{
+		updatedSinceOptions: updatedSinceOptions{UpdatedSince: updatedSince(d)},
 	}
 
-	for {
-		releases, res, err := client.Releases.ListReleases(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_release.listReleases", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain releases: %v", err)
-		}
-
-		for _, release := range releases.Collection {
-			d.StreamListItem(ctx, release)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, "releases", &filter, &filter.ListOptions, func(releases *releaseItems) []releaseItem {
+			return releases.Collection
+		})
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_release.listReleases", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain releases: %w", err)
 	}
 
 	return nil, nil
 }
 
-// Transform Functions
-func releasePriorityDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	case 4:
-		return "Urgent", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func releaseStatusDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Open", nil
-	case 2:
-		return "On Hold", nil
-	case 3:
-		return "In Progress", nil
-	case 4:
-		return "Incomplete", nil
-	case 5:
-		return "Completed", nil
-	default:
-		return "Unknown", nil
-	}
-}
-
-func releaseTypeDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Minor", nil
-	case 2:
-		return "Standard", nil
-	case 3:
-		return "Major", nil
-	case 4:
-		return "Emergency", nil
-	default:
-		return "Unknown", nil
-	}
-}
+// Labels of the release fields which have a *_desc column, the defaults are used when the release form can't be read.
+var (
+	releasePriority = formLabelField{Name: "priority", Field: "Priority", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High", 4: "Urgent",
+	}}
+	releaseStatus = formLabelField{Name: "status", Field: "Status", Defaults: map[int]string{
+		1: "Open", 2: "On Hold", 3: "In Progress", 4: "Incomplete", 5: "Completed",
+	}}
+	releaseType = formLabelField{Name: "release_type", Field: "ReleaseType", Defaults: map[int]string{
+		1: "Minor", 2: "Standard", 3: "Major", 4: "Emergency",
+	}}
+)
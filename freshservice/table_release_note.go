@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -81,10 +82,11 @@ func listReleaseNotes(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	notes, _, err := client.Releases.ListReleaseNotes(releaseId)
+	notes := new(fs.Notes)
+	_, err = client.list(ctx, fmt.Sprintf("releases/%d/notes", releaseId), nil, notes)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_release_note.listReleaseNotes", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain release notes: %v", err)
+		return nil, fmt.Errorf("unable to obtain release notes: %w", err)
 	}
 
 	for _, note := range notes.Collection {
@@ -152,10 +152,11 @@ func getRequester(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	requester, _, err := client.Requesters.GetRequester(id)
+	requester := new(fs.Requester)
+	err = client.get(ctx, fmt.Sprintf("requesters/%d", id), "requester", requester)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_requester.getRequester", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain requester with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain requester with id %d: %w", id, err)
 	}
 
 	return requester, nil
@@ -171,41 +172,20 @@ func listRequesters(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 	ia := true
 	q := d.EqualsQuals
 	filter := fs.ListRequestersOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
 		IncludeAgents: &ia,
 	}
 
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
-
 	if q["email"] != nil {
 		e := q["email"].GetStringValue()
 		filter.Email = &e
 	}
 
-	for {
-		users, res, err := client.Requesters.ListRequesters(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_requester.listRequesters", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain requesters: %v", err)
-		}
-
-		for _, user := range users.Collection {
-			d.StreamListItem(ctx, user)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "requesters", &filter, &filter.ListOptions, func(users *fs.Requesters) []fs.Requester {
+		return users.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_requester.listRequesters", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain requesters: %w", err)
 	}
 
 	return nil, nil
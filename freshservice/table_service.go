@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"net/url"
+	"strconv"
 )
 
 func tableService() *plugin.Table {
@@ -13,6 +16,9 @@ func tableService() *plugin.Table {
 		Description: "Obtains information about Service Items from the Service Catalog on the FreshService instance.",
 		List: &plugin.ListConfig{
 			Hydrate: listServiceItems,
+			KeyColumns: []*plugin.KeyColumn{
+				workspaceIdKeyColumn(),
+			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getServiceItem,
@@ -78,6 +84,7 @@ func serviceColumns() []*plugin.Column {
 			Name:        "ci_type_id",
 			Description: "ID of the asset type associated with the product.",
 			Type:        proto.ColumnType_INT,
+			Transform:   transform.FromField("CITypeID"),
 		},
 		{
 			Name:        "visibility",
@@ -144,6 +151,7 @@ func serviceColumns() []*plugin.Column {
 			Description: "Timestamp when the service item was updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -157,10 +165,11 @@ func getServiceItem(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	service, _, err := client.Services.GetServiceItem(id)
+	service := new(serviceItem)
+	err = client.get(ctx, fmt.Sprintf("service_catalog/items/%d", id), "service_item", service)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_service.getServiceItem", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain service item with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain service item with id %d: %w", id, err)
 	}
 
 	return service, nil
@@ -174,14 +183,26 @@ func listServiceItems(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 
 	// Note: List operation returns all results without pagination.
-	serviceItems, _, err := client.Services.ListServiceItems()
-	if err != nil {
-		plugin.Logger(ctx).Error("freshservice_service.listServiceItems", "connection_error", err)
-		return nil, fmt.Errorf("unable to obtain service items: %v", err)
-	}
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		var q url.Values
+		if workspaceID != nil {
+			q = url.Values{"workspace_id": {strconv.Itoa(*workspaceID)}}
+		}
 
-	for _, serviceItem := range serviceItems.Collection {
-		d.StreamListItem(ctx, serviceItem)
+		items := new(serviceItems)
+		if _, err := client.list(ctx, "service_catalog/items", q, items); err != nil {
+			return err
+		}
+
+		for _, item := range items.Collection {
+			d.StreamListItem(ctx, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_service.listServiceItems", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain service items: %w", err)
 	}
 
 	return nil, nil
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -60,6 +61,7 @@ func slaColumns() []*plugin.Column {
 			Name:        "sla_targets",
 			Description: "Array of policy targets.",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("Targets"),
 		},
 		{
 			Name:        "category",
@@ -136,10 +138,11 @@ func listSLAs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	slas, _, err := client.ServiceLevelAgreements.ListPolicies()
+	slas := new(fs.Policies)
+	_, err = client.list(ctx, "sla_policies", nil, slas)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_sla_policy.listSLAs", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain sla policies: %v", err)
+		return nil, fmt.Errorf("unable to obtain sla policies: %w", err)
 	}
 
 	for _, sla := range slas.Collection {
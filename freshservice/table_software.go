@@ -103,10 +103,11 @@ func getSoftware(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	software, _, err := client.Software.GetApplication(id)
+	software := new(fs.Application)
+	err = client.get(ctx, fmt.Sprintf("applications/%d", id), "application", software)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_software.getSoftware", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain software with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain software with id %d: %w", id, err)
 	}
 
 	return software, nil
@@ -119,36 +120,14 @@ func listSoftware(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListApplicationsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListApplicationsOptions{}
 
-	for {
-		allSoftware, res, err := client.Software.ListApplications(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_software.listSoftware", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain software: %v", err)
-		}
-
-		for _, software := range allSoftware.Collection {
-			d.StreamListItem(ctx, software)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "applications", &filter, &filter.ListOptions, func(allSoftware *fs.Applications) []fs.Application {
+		return allSoftware.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_software.listSoftware", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain software: %w", err)
 	}
 
 	return nil, nil
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -92,10 +93,11 @@ func listSoftwareInstallations(ctx context.Context, d *plugin.QueryData, h *plug
 	q := d.EqualsQuals
 	s := int(q["software_id"].GetInt64Value())
 
-	installs, _, err := client.Software.ListInstallations(s)
+	installs := new(fs.SoftwareInstallations)
+	_, err = client.list(ctx, fmt.Sprintf("applications/%d/installations", s), nil, installs)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_software_installation.listSoftwareInstallations", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain software installations: %v", err)
+		return nil, fmt.Errorf("unable to obtain software installations: %w", err)
 	}
 
 	for _, install := range installs.Collection {
@@ -89,49 +89,28 @@ func listSoftwareUsers(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListSoftwareUsersOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListSoftwareUsersOptions{}
 
 	q := d.EqualsQuals
 	s := int(q["software_id"].GetInt64Value())
 
 	if q["id"] != nil {
 		u := int(q["id"].GetInt64Value())
-		user, _, err := client.Software.GetSoftwareUser(s, u)
+		user := new(fs.SoftwareUser)
+		err = client.get(ctx, fmt.Sprintf("applications/%d/users/%d", s, u), "application_user", user)
 		if err != nil {
 			plugin.Logger(ctx).Error("freshservice_software_user.listSoftwareUsers", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain software user: %v", err)
+			return nil, fmt.Errorf("unable to obtain software user: %w", err)
 		}
 
 		d.StreamListItem(ctx, user)
 	} else {
-		for {
-			users, res, err := client.Software.ListSoftwareUsers(s, &filter)
-			if err != nil {
-				plugin.Logger(ctx).Error("freshservice_software_user.listSoftwareUsers", "query_error", err)
-				return nil, fmt.Errorf("unable to obtain software users: %v", err)
-			}
-
-			for _, user := range users.Collection {
-				d.StreamListItem(ctx, user)
-			}
-
-			if res.Header.Get("link") == "" {
-				break
-			}
-
-			filter.Page += 1
+		err = paginate(ctx, d, client, fmt.Sprintf("applications/%d/users", s), &filter, &filter.ListOptions, func(users *fs.SoftwareUsers) []fs.SoftwareUser {
+			return users.Collection
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("freshservice_software_user.listSoftwareUsers", "query_error", err)
+			return nil, fmt.Errorf("unable to obtain software users: %w", err)
 		}
 	}
 
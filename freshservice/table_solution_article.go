@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tableSolutionArticle() *plugin.Table {
@@ -110,6 +111,7 @@ func solutionArticleColumns() []*plugin.Column {
 			Name:        "url",
 			Description: "External url for the Solution Article.",
 			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Url"),
 		},
 		{
 			Name:        "review_date",
@@ -139,10 +141,11 @@ func getSolutionArticle(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	article, _, err := client.Solutions.GetSolutionArticle(id)
+	article := new(fs.SolutionArticle)
+	err = client.get(ctx, fmt.Sprintf("solutions/articles/%d", id), "article", article)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_solution_article.getSolutionArticle", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain solution article with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain solution article with id %d: %w", id, err)
 	}
 
 	return article, nil
@@ -155,19 +158,7 @@ func listSolutionArticles(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListSolutionArticlesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListSolutionArticlesOptions{}
 
 	q := d.EqualsQuals
 	if q["folder_id"] != nil {
@@ -175,22 +166,12 @@ func listSolutionArticles(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		filter.FolderID = fid
 	}
 
-	for {
-		articles, res, err := client.Solutions.ListSolutionArticles(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_solution_article.listSolutionArticles", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain solution articles: %v", err)
-		}
-
-		for _, article := range articles.Collection {
-			d.StreamListItem(ctx, article)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "solutions/articles", &filter, &filter.ListOptions, func(articles *fs.SolutionArticles) []fs.SolutionArticle {
+		return articles.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_solution_article.listSolutionArticles", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain solution articles: %w", err)
 	}
 
 	return nil, nil
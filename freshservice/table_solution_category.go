@@ -19,6 +19,7 @@ func tableSolutionCategory() *plugin.Table {
 					Name:    "id",
 					Require: plugin.Optional,
 				},
+				workspaceIdKeyColumn(),
 			},
 		},
 		Columns: solutionCategoryColumns(),
@@ -67,6 +68,7 @@ func solutionCategoryColumns() []*plugin.Column {
 			Description: "Timestamp when the solution category was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -78,49 +80,34 @@ func listSolutionCategories(ctx context.Context, d *plugin.QueryData, h *plugin.
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListSolutionCategoriesOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := struct {
+		fs.ListSolutionCategoriesOptions
+		workspaceOptions
+	}{}
 
 	q := d.EqualsQuals
 
 	if q["id"] != nil {
 		catId := int(q["id"].GetInt64Value())
 
-		category, _, err := client.Solutions.GetSolutionCategory(catId)
+		category := new(solutionCategoryItem)
+		err = client.get(ctx, fmt.Sprintf("solutions/categories/%d", catId), "category", category)
 		if err != nil {
 			plugin.Logger(ctx).Error("freshservice_solution_category.listSolutionCategories", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain solution category with id %d: %v", catId, err)
+			return nil, fmt.Errorf("unable to obtain solution category with id %d: %w", catId, err)
 		}
 
 		d.StreamListItem(ctx, category)
 	} else {
-		for {
-			categories, res, err := client.Solutions.ListSolutionCategories(&filter)
-			if err != nil {
-				plugin.Logger(ctx).Error("freshservice_solution_category.listSolutionCategories", "query_error", err)
-				return nil, fmt.Errorf("unable to obtain solution categories: %v", err)
-			}
-
-			for _, category := range categories.Collection {
-				d.StreamListItem(ctx, category)
-			}
-
-			if res.Header.Get("link") == "" {
-				break
-			}
-
-			filter.Page += 1
+		err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+			filter.WorkspaceID = workspaceID
+			return paginate(ctx, d, client, "solutions/categories", &filter, &filter.ListOptions, func(categories *solutionCategoryItems) []solutionCategoryItem {
+				return categories.Collection
+			})
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("freshservice_solution_category.listSolutionCategories", "query_error", err)
+			return nil, fmt.Errorf("unable to obtain solution categories: %w", err)
 		}
 	}
 
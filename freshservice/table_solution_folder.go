@@ -6,6 +6,7 @@ import (
 	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 func tableSolutionFolder() *plugin.Table {
@@ -51,6 +52,7 @@ func solutionFolderColumns() []*plugin.Column {
 			Name:        "default_category",
 			Description: "Set to true if the solution folder is the default one.",
 			Type:        proto.ColumnType_BOOL,
+			Transform:   transform.FromField("DefaultFolder"),
 		},
 		{
 			Name:        "category_id",
@@ -66,21 +68,25 @@ func solutionFolderColumns() []*plugin.Column {
 			Name:        "department_ids",
 			Description: "Array of IDs of the departments to which this solution folder is visible.",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("DepartmentIDs"),
 		},
 		{
 			Name:        "group_ids",
 			Description: "Array of IDs of the agent groups to which this solution folder is visible.",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("GroupIDs"),
 		},
 		{
 			Name:        "requester_group_ids",
 			Description: "Array of IDs of requester groups to which this solution folder is visible.",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("RequesterGroupIDs"),
 		},
 		{
 			Name:        "manage_by_group_ids",
 			Description: "Array of IDs of groups which manage this solution folder.",
 			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("ManageByGroupIDs"),
 		},
 		{
 			Name:        "created_at",
@@ -103,42 +109,28 @@ func listSolutionFolders(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListSolutionFoldersOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
+	filter := fs.ListSolutionFoldersOptions{}
 
 	q := d.EqualsQuals
 
 	if q["id"] != nil {
 		folderId := int(q["id"].GetInt64Value())
 
-		folder, _, err := client.Solutions.GetSolutionFolder(folderId)
+		folder := new(fs.SolutionFolder)
+		err = client.get(ctx, fmt.Sprintf("solutions/folders/%d", folderId), "folder", folder)
 		if err != nil {
 			plugin.Logger(ctx).Error("freshservice_solution_folder.listSolutionFolders", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain solution folder with id %d: %v", folderId, err)
+			return nil, fmt.Errorf("unable to obtain solution folder with id %d: %w", folderId, err)
 		}
 
 		d.StreamListItem(ctx, folder)
 	} else {
-		for {
-			folders, res, err := client.Solutions.ListSolutionFolders(&filter)
-			if err != nil {
-				plugin.Logger(ctx).Error("freshservice_solution_folder.listSolutionFolders", "query_error", err)
-				return nil, fmt.Errorf("unable to obtain solution folders: %v", err)
-			}
-
-			for _, folder := range folders.Collection {
-				d.StreamListItem(ctx, folder)
-			}
-
-			if res.Header.Get("link") == "" {
-				break
-			}
-
-			filter.Page += 1
+		err = paginate(ctx, d, client, "solutions/folders", &filter, &filter.ListOptions, func(folders *fs.SolutionFolders) []fs.SolutionFolder {
+			return folders.Collection
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("freshservice_solution_folder.listSolutionFolders", "query_error", err)
+			return nil, fmt.Errorf("unable to obtain solution folders: %w", err)
 		}
 	}
 
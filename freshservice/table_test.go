@@ -0,0 +1,760 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"steampipe-plugin-freshservice/freshservice/internal/fakeapi"
+	"strings"
+	"testing"
+	"time"
+)
+
+type quals map[string]interface{}
+
+func ts(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// tableTests query every table through its List hydrate and, where the table has one, its Get hydrate. want holds
+// the expected values of some columns of the row with the same id.
+var tableTests = []struct {
+	name  string
+	table string
+	quals quals
+	rows  int
+	want  row
+}{
+	{
+		name:  "list",
+		table: "freshservice_agent",
+		rows:  2,
+		want: row{
+			"id":         int64(1),
+			"first_name": "Ada",
+			"last_name":  "Lovelace",
+			"email":      "ada@example.com",
+			"active":     true,
+			"created_at": ts("2023-01-02T03:04:05Z"),
+		},
+	},
+	{
+		name:  "get",
+		table: "freshservice_agent",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "first_name": "Alan", "occasional": true},
+	},
+	{
+		name:  "list",
+		table: "freshservice_agent_role",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "Account Admin", "default": true},
+	},
+	{
+		name:  "get",
+		table: "freshservice_agent_role",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Agent"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_announcement",
+		rows:  2,
+		want:  row{"id": int64(1), "title": "Maintenance window", "state": "active"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_announcement",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "title": "Office move", "visibility": "agents_only"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_asset",
+		rows:  2,
+		want:  row{"id": int64(51), "display_id": int64(1001), "name": "Laptop 1", "asset_tag": "ASSET-1001"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_asset",
+		quals: quals{"display_id": 1002},
+		rows:  1,
+		want: row{"id": int64(52), "display_id": int64(1002), "name": "Printer", "type_fields": map[string]interface{}{
+			"serial_number_1": "SN-1002", "cost_1": float64(300), "color_2": true, "pages_per_minute_2": float64(40),
+		}},
+	},
+	{
+		name:  "list",
+		table: "freshservice_asset_component",
+		quals: quals{"asset_display_id": 1001},
+		rows:  2,
+		want:  row{"id": int64(1), "component_type": "Processor", "asset_display_id": int64(1001)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_asset_contract",
+		quals: quals{"asset_display_id": 1001},
+		rows:  1,
+		want:  row{"id": int64(1), "contract_name": "Laptop warranty", "asset_display_id": int64(1001)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_asset_type",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "Hardware", "visible": true},
+	},
+	{
+		name:  "get",
+		table: "freshservice_asset_type",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Printer", "parent_asset_type_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_business_hour",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Default", "is_default": true, "time_zone": "Europe/London"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_business_hour",
+		quals: quals{"id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Default"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_change",
+		rows:  2,
+		want:  row{"id": int64(1), "subject": "Database upgrade", "requester_id": int64(1), "planned_start_date": ts("2023-04-01T10:00:00Z")},
+	},
+	{
+		name:  "get",
+		table: "freshservice_change",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "subject": "Switch replacement", "type": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_change_note",
+		quals: quals{"change_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "body_text": "Approved by CAB", "change_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_contract",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Laptop lease", "auto_renew": true, "contract_number": "CNT-1"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_contract",
+		quals: quals{"id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Laptop lease"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_contract_type",
+		rows:  2,
+		want:  row{"id": int64(2), "name": "Warranty", "needs_approval": false},
+	},
+	{
+		name:  "list",
+		table: "freshservice_department",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "Engineering", "head_user_id": int64(1), "domains": []interface{}{"example.com"}},
+	},
+	{
+		name:  "get",
+		table: "freshservice_department",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Finance"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_location",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "London", "line1": "1 High Street", "city": "London", "country": "United Kingdom"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_location",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Manchester", "parent_location_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_problem",
+		rows:  2,
+		want:  row{"id": int64(1), "subject": "Email outage", "known_error": false, "due_by": ts("2023-05-01T00:00:00Z")},
+	},
+	{
+		name:  "get",
+		table: "freshservice_problem",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "subject": "Slow VPN", "known_error": true},
+	},
+	{
+		name:  "list",
+		table: "freshservice_problem_note",
+		quals: quals{"problem_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "body_text": "Root cause found", "problem_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_problem_task",
+		quals: quals{"problem_id": 1},
+		rows:  2,
+		want:  row{"id": int64(2), "title": "Apply patch", "problem_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_problem_timeentry",
+		quals: quals{"problem_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "time_spent": "01:30", "billable": true, "problem_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_product",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "ThinkPad X1", "manufacturer": "Lenovo"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_product",
+		quals: quals{"id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "name": "ThinkPad X1"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_purchase_order",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Laptops", "po_number": "PO-1", "currency_code": "GBP"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_purchase_order",
+		quals: quals{"id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Laptops"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_release",
+		rows:  2,
+		want:  row{"id": int64(1), "subject": "Q2 release", "release_type_desc": "Standard", "associated_changes": []interface{}{1.0}},
+	},
+	{
+		name:  "get",
+		table: "freshservice_release",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "subject": "Hotfix"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_release_note",
+		quals: quals{"release_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "body_text": "Deployed to staging", "release_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_release_task",
+		quals: quals{"release_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "title": "Deploy", "release_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_release_timeentry",
+		quals: quals{"release_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "time_spent": "00:45", "release_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_requester",
+		rows:  2,
+		want:  row{"id": int64(1), "first_name": "Grace", "email": "grace@example.com", "active": true},
+	},
+	{
+		name:  "get",
+		table: "freshservice_requester",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "first_name": "Linus", "job_title": "Maintainer"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_service",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "New laptop", "delivery_time": int64(48)},
+	},
+	{
+		name:  "get",
+		table: "freshservice_service",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "VPN access"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_sla_policy",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Default SLA", "is_default": true},
+	},
+	{
+		name:  "list",
+		table: "freshservice_software",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "Slack", "application_type": "saas", "user_count": int64(2)},
+	},
+	{
+		name:  "get",
+		table: "freshservice_software",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Firefox"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_software_installation",
+		quals: quals{"software_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "version": "4.29", "software_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_software_user",
+		quals: quals{"software_id": 1},
+		rows:  2,
+		want:  row{"id": int64(1), "user_id": int64(1), "software_id": int64(1)},
+	},
+	{
+		name:  "get",
+		table: "freshservice_software_user",
+		quals: quals{"software_id": 1, "id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "user_id": int64(2), "software_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_solution_article",
+		rows:  2,
+		want:  row{"id": int64(1), "title": "Resetting your password", "views": int64(100), "tags": []interface{}{"password"}},
+	},
+	{
+		name:  "get",
+		table: "freshservice_solution_article",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "title": "Connecting to VPN"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_solution_category",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "General", "default_category": true},
+	},
+	{
+		name:  "get",
+		table: "freshservice_solution_category",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Hardware"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_solution_folder",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "Accounts", "category_id": int64(1)},
+	},
+	{
+		name:  "get",
+		table: "freshservice_solution_folder",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "Network"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_ticket",
+		rows:  3,
+		want: row{
+			"id":          int64(1),
+			"subject":     "Cannot login",
+			"status":      int64(2),
+			"status_desc": "Open",
+			"priority":    int64(1),
+			"tags":        []interface{}{"login"},
+			"due_by":      ts("2023-01-05T00:00:00Z"),
+			"fr_due_by":   ts("2023-01-03T00:00:00Z"),
+		},
+	},
+	{
+		name:  "get",
+		table: "freshservice_ticket",
+		quals: quals{"id": 3},
+		rows:  1,
+		want:  row{"id": int64(3), "subject": "Printer jam", "status_desc": "Closed", "priority_desc": "P1", "workspace_id": int64(2)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_ticket_conversation",
+		quals: quals{"ticket_id": 1},
+		rows:  2,
+		want:  row{"id": int64(2), "body_text": "That worked", "incoming": true},
+	},
+	{
+		name:  "list",
+		table: "freshservice_ticket_task",
+		quals: quals{"ticket_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "title": "Reset password", "ticket_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_ticket_timeentry",
+		quals: quals{"ticket_id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "time_spent": "00:15", "ticket_id": int64(1)},
+	},
+	{
+		name:  "list",
+		table: "freshservice_vendor",
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Lenovo", "city": "Morrisville"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_vendor",
+		quals: quals{"id": 1},
+		rows:  1,
+		want:  row{"id": int64(1), "name": "Lenovo"},
+	},
+	{
+		name:  "list",
+		table: "freshservice_workspace",
+		rows:  2,
+		want:  row{"id": int64(1), "name": "IT", "primary": true, "template_name": "it"},
+	},
+	{
+		name:  "get",
+		table: "freshservice_workspace",
+		quals: quals{"id": 2},
+		rows:  1,
+		want:  row{"id": int64(2), "name": "HR", "restricted": true},
+	},
+}
+
+func TestTables(t *testing.T) {
+	p := startPlugin(t, "")
+	domain := apiHost(p.api)
+
+	for _, tt := range tableTests {
+		tt := tt
+		t.Run(tt.table+"/"+tt.name, func(t *testing.T) {
+			rows := p.mustQuery(t, tt.table, tt.quals, 0)
+
+			if len(rows) != tt.rows {
+				t.Fatalf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+
+			assertRow(t, rows, tt.want)
+			for _, r := range rows {
+				if r["domain"] != domain {
+					t.Errorf("expected domain to be %s, got %v", domain, r["domain"])
+				}
+			}
+		})
+	}
+}
+
+func TestWorkspaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		table      string
+		quals      quals
+		workspaces []string
+		ids        []int64
+	}{
+		{name: "primary", table: "freshservice_ticket", workspaces: []string{""}, ids: []int64{1, 2, 3}},
+		{name: "qual", table: "freshservice_ticket", quals: quals{"workspace_id": 2}, workspaces: []string{"2"}, ids: []int64{3}},
+		{name: "qual list", table: "freshservice_change", quals: quals{"workspace_id": []int{1, 2}}, workspaces: []string{"1", "2"}, ids: []int64{1, 2}},
+		{name: "config", config: "workspace_ids = [2]", table: "freshservice_ticket", workspaces: []string{"2"}, ids: []int64{3}},
+		{name: "config all", config: "workspace_ids = [0]", table: "freshservice_change", workspaces: []string{"0"}, ids: []int64{1, 2}},
+		{name: "qual overrides config", config: "workspace_ids = [2]", table: "freshservice_ticket", quals: quals{"workspace_id": 1}, workspaces: []string{"1"}, ids: []int64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		p := startPlugin(t, tt.config)
+
+		rows, err := p.query(t, tt.table, tt.quals, 0)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		var ids []int64
+		for _, r := range rows {
+			ids = append(ids, r["id"].(int64))
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("%s: expected ids %v, got %v", tt.name, tt.ids, ids)
+		}
+
+		var workspaces []string
+		for _, u := range p.api.RequestsTo(strings.TrimPrefix(tt.table, "freshservice_") + "s") {
+			workspaces = append(workspaces, u.Query().Get("workspace_id"))
+		}
+		// the values of a qual list are listed concurrently
+		sort.Strings(workspaces)
+		if !reflect.DeepEqual(workspaces, tt.workspaces) {
+			t.Errorf("%s: expected requests for workspaces %q, got %q", tt.name, tt.workspaces, workspaces)
+		}
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	it := fakeapi.New(testToken)
+	hr := fakeapi.New(testToken)
+	// ticket 2 only exists in the IT instance
+	hr.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/api/v2/tickets/2" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"The requested resource does not exist."}`))
+			return true
+		}
+		return false
+	}
+
+	p := startAggregator(t, it, hr)
+
+	tests := []struct {
+		name    string
+		table   string
+		quals   quals
+		domains map[string]int
+	}{
+		{name: "list", table: "freshservice_agent", domains: map[string]int{apiHost(it): 2, apiHost(hr): 2}},
+		{name: "get from both", table: "freshservice_ticket", quals: quals{"id": 1}, domains: map[string]int{apiHost(it): 1, apiHost(hr): 1}},
+		{name: "get from one", table: "freshservice_ticket", quals: quals{"id": 2}, domains: map[string]int{apiHost(it): 1}},
+		// the agents, ticket & ticket form endpoints have been used
+		{name: "api usage", table: "freshservice_api_usage", domains: map[string]int{apiHost(it): 3, apiHost(hr): 3}},
+	}
+
+	for _, tt := range tests {
+		rows, err := p.query(t, tt.table, tt.quals, 0)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		domains := make(map[string]int)
+		for _, r := range rows {
+			domain, _ := r["domain"].(string)
+			domains[domain]++
+		}
+		if !reflect.DeepEqual(domains, tt.domains) {
+			t.Errorf("%s: expected rows from %v, got %v", tt.name, tt.domains, domains)
+		}
+	}
+}
+
+// apiHost returns the host of api, which is the domain of the rows obtained from it.
+func apiHost(api *fakeapi.Server) string {
+	u, _ := url.Parse(api.BaseUrl())
+	return u.Host
+}
+
+func TestTablesCoverEveryTable(t *testing.T) {
+	// tables which don't read from FreshService are covered by their own tests
+	tested := map[string]bool{
+		"freshservice_api_usage": true,
+	}
+	for _, tt := range tableTests {
+		tested[tt.table] = true
+	}
+
+	for name := range tableMap() {
+		if !tested[name] {
+			t.Errorf("table %s has no test", name)
+		}
+	}
+}
+
+func TestQualPushdown(t *testing.T) {
+	p := startPlugin(t, "")
+
+	tests := []struct {
+		table string
+		path  string
+		quals quals
+		param string
+		value string
+		rows  int
+	}{
+		{table: "freshservice_agent", path: "agents", quals: quals{"email": "alan@example.com"}, param: "email", value: "alan@example.com", rows: 1},
+		{table: "freshservice_agent", path: "agents", quals: quals{"active": false}, param: "active", value: "false", rows: 1},
+		{table: "freshservice_announcement", path: "announcements", quals: quals{"state": "archived"}, param: "state", value: "archived", rows: 1},
+		{table: "freshservice_change", path: "changes", quals: quals{"requester_id": 2}, param: "requester_id", value: "2", rows: 1},
+		{table: "freshservice_requester", path: "requesters", quals: quals{"email": "grace@example.com"}, param: "email", value: "grace@example.com", rows: 1},
+		{table: "freshservice_solution_article", path: "solutions/articles", quals: quals{"folder_id": 2}, param: "folder_id", value: "2", rows: 1},
+		{table: "freshservice_ticket", path: "tickets", quals: quals{"requester_id": 1}, param: "requester_id", value: "1", rows: 2},
+		{table: "freshservice_ticket", path: "tickets", quals: quals{"type": "Service Request"}, param: "type", value: "Service Request", rows: 1},
+		{table: "freshservice_ticket", path: "tickets", quals: quals{"workspace_id": 2}, param: "workspace_id", value: "2", rows: 1},
+		{table: "freshservice_change", path: "changes", quals: quals{"workspace_id": 1}, param: "workspace_id", value: "1", rows: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.table+"/"+tt.param, func(t *testing.T) {
+			p.api.Reset()
+
+			rows := p.mustQuery(t, tt.table, tt.quals, 0)
+			if len(rows) != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+
+			requests := p.api.RequestsTo(tt.path)
+			if len(requests) != 1 {
+				t.Fatalf("expected a single request to %s, got %d", tt.path, len(requests))
+			}
+			if got := requests[0].Query().Get(tt.param); got != tt.value {
+				t.Errorf("expected %s=%s to be passed to the API, got '%s'", tt.param, tt.value, got)
+			}
+		})
+	}
+}
+
+func TestAuthenticationFailure(t *testing.T) {
+	p := startPlugin(t, "")
+	p.api.Token = "another-token"
+
+	if _, err := p.query(t, "freshservice_agent", nil, 0); err == nil {
+		t.Fatal("expected the query to fail")
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	tests := []struct {
+		table string
+		quals quals
+		rows  int
+	}{
+		{table: "freshservice_ticket", quals: quals{"id": 999}, rows: 0},
+		{table: "freshservice_ticket", quals: quals{"id": []int{1, 2, 999}}, rows: 2},
+		{table: "freshservice_asset", quals: quals{"display_id": 999}, rows: 0},
+		{table: "freshservice_ticket_task", quals: quals{"ticket_id": 999}, rows: 0},
+		{table: "freshservice_asset_component", quals: quals{"asset_display_id": 999}, rows: 0},
+	}
+
+	p := startPlugin(t, "")
+	for _, tt := range tests {
+		rows, err := p.query(t, tt.table, tt.quals, 0)
+		if err != nil {
+			t.Errorf("%s %v: expected a missing record to return no row, got %v", tt.table, tt.quals, err)
+			continue
+		}
+		if len(rows) != tt.rows {
+			t.Errorf("%s %v: expected %d rows, got %d", tt.table, tt.quals, tt.rows, len(rows))
+		}
+	}
+}
+
+func TestFilteredListNotFound(t *testing.T) {
+	p := startPlugin(t, "")
+	p.api.Intercept = func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/api/v2/tickets/filter" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"The requested resource does not exist."}`))
+			return true
+		}
+		return false
+	}
+
+	if _, err := p.query(t, "freshservice_ticket", quals{"status": 2}, 0); err == nil {
+		t.Error("expected a 404 from a filtered list to fail the query")
+	}
+}
+
+func TestShouldIgnoreNotFound(t *testing.T) {
+	notFound := fmt.Errorf("unable to obtain ticket: %w", &apiError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Path: "tickets/1", kind: errNotFound})
+	filterNotFound := fmt.Errorf("unable to obtain tickets: %w", &apiError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Path: "tickets/filter", kind: errNotFound})
+	forbidden := fmt.Errorf("unable to obtain ticket: %w", &apiError{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Path: "tickets/1", kind: errForbidden})
+	qualified := map[string]*proto.QualValue{"id": {Value: &proto.QualValue_Int64Value{Int64Value: 1}}}
+	filtered := map[string]*proto.QualValue{"status": {Value: &proto.QualValue_Int64Value{Int64Value: 2}}}
+	listed := map[string]*proto.QualValue{"id": {Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: []*proto.QualValue{
+		{Value: &proto.QualValue_Int64Value{Int64Value: 2}},
+		{Value: &proto.QualValue_Int64Value{Int64Value: 1}},
+	}}}}}
+
+	tests := []struct {
+		name   string
+		err    error
+		quals  map[string]*proto.QualValue
+		ignore bool
+	}{
+		{name: "qualified not found", err: notFound, quals: qualified, ignore: true},
+		{name: "listed not found", err: notFound, quals: listed, ignore: true},
+		{name: "unqualified not found", err: notFound, ignore: false},
+		{name: "filtered list not found", err: filterNotFound, quals: filtered, ignore: false},
+		{name: "other record not found", err: notFound, quals: filtered, ignore: false},
+		{name: "qualified forbidden", err: forbidden, quals: qualified, ignore: false},
+		{name: "other error", err: fmt.Errorf("error sending request: timeout"), quals: qualified, ignore: false},
+	}
+
+	for _, tt := range tests {
+		d := &plugin.QueryData{EqualsQuals: tt.quals}
+		if got := shouldIgnoreNotFound(context.Background(), d, nil, tt.err); got != tt.ignore {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.ignore, got)
+		}
+	}
+}
+
+// assertRow checks the row with the id of want has the values in want.
+func assertRow(t *testing.T, rows []row, want row) {
+	t.Helper()
+
+	for _, r := range rows {
+		if r["id"] != want["id"] {
+			continue
+		}
+
+		for column, value := range want {
+			if !reflect.DeepEqual(r[column], value) {
+				t.Errorf("expected %s to be %v (%T), got %v (%T)", column, value, value, r[column], r[column])
+			}
+		}
+		return
+	}
+
+	t.Errorf("no row with id %v", want["id"])
+}
@@ -15,7 +15,7 @@ func tableTicket() *plugin.Table {
 		Description: "Obtain information on Tickets raised in the FreshService instance.",
 		List: &plugin.ListConfig{
 			Hydrate: listTickets,
-			KeyColumns: []*plugin.KeyColumn{
+			KeyColumns: append([]*plugin.KeyColumn{
 				{
 					Name:    "email",
 					Require: plugin.Optional,
@@ -28,13 +28,15 @@ func tableTicket() *plugin.Table {
 					Name:    "type",
 					Require: plugin.Optional,
 				},
-			},
+				workspaceIdKeyColumn(),
+			}, ticketFilterKeyColumns()...),
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getTicket,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: ticketColumns(),
+		HydrateConfig: formLabelsHydrateConfig(),
+		Columns:       ticketColumns(),
 	}
 }
 
@@ -89,7 +91,8 @@ func ticketColumns() []*plugin.Column {
 			Name:        "status_desc",
 			Description: "Description of the ticket status.",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Status").Transform(ticketStatusDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, ticketStatus),
 		},
 		{
 			Name:        "priority",
@@ -100,7 +103,8 @@ func ticketColumns() []*plugin.Column {
 			Name:        "priority_desc",
 			Description: "Description of the ticket priority",
 			Type:        proto.ColumnType_STRING,
-			Transform:   transform.FromField("Priority").Transform(ticketPriorityDesc),
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, ticketPriority),
 		},
 		{
 			Name:        "category",
@@ -127,11 +131,25 @@ func ticketColumns() []*plugin.Column {
 			Description: "Ticket urgency.",
 			Type:        proto.ColumnType_INT,
 		},
+		{
+			Name:        "urgency_desc",
+			Description: "Description of the ticket urgency.",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, ticketUrgency),
+		},
 		{
 			Name:        "impact",
 			Description: "Ticket impact.",
 			Type:        proto.ColumnType_INT,
 		},
+		{
+			Name:        "impact_desc",
+			Description: "Description of the ticket impact.",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, ticketImpact),
+		},
 		{
 			Name:        "responder_id",
 			Description: "ID of the agent to whom the ticket has been assigned.",
@@ -141,11 +159,13 @@ func ticketColumns() []*plugin.Column {
 			Name:        "fr_due_by",
 			Description: "Timestamp that denotes when the first response is due.",
 			Type:        proto.ColumnType_TIMESTAMP,
+			Transform:   transform.FromField("FirstResponseDueBy"),
 		},
 		{
 			Name:        "fr_escalated",
 			Description: "Set to true if the ticket has been escalated as a result of the first response time being breached.",
 			Type:        proto.ColumnType_BOOL,
+			Transform:   transform.FromField("FirstResponseEscalated"),
 		},
 		{
 			Name:        "due_by",
@@ -182,11 +202,24 @@ func ticketColumns() []*plugin.Column {
 			Description: "The channel through which the ticket was created.",
 			Type:        proto.ColumnType_INT,
 		},
+		{
+			Name:        "source_desc",
+			Description: "Description of the ticket source.",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getFormLabels,
+			Transform:   transform.FromP(formLabel, ticketSource),
+		},
 		{
 			Name:        "tags",
 			Description: "Array of tags that have been associated with the ticket.",
 			Type:        proto.ColumnType_JSON,
 		},
+		{
+			Name:        "tag",
+			Description: "Tag to list the tickets of, such as where tag = 'login'. Only populated when given in the query, see tags for every tag of the ticket.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("tag"),
+		},
 		{
 			Name:        "attachments",
 			Description: "Ticket attachments.",
@@ -202,6 +235,12 @@ func ticketColumns() []*plugin.Column {
 			Description: "Timestamp when the ticket was last updated.",
 			Type:        proto.ColumnType_TIMESTAMP,
 		},
+		{
+			Name:        "custom_fields",
+			Description: "Values of the custom fields of the ticket, keyed by field name.",
+			Type:        proto.ColumnType_JSON,
+		},
+		workspaceIdColumn(),
 	}
 }
 
@@ -215,10 +254,11 @@ func getTicket(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	ticket, _, err := client.Tickets.GetTicket(id)
+	ticket := new(ticketItem)
+	err = client.get(ctx, fmt.Sprintf("tickets/%d", id), "ticket", ticket)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_ticket.getTicket", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain ticket with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain ticket with id %d: %w", id, err)
 	}
 
 	return ticket, nil
@@ -231,95 +271,69 @@ func listTickets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListTicketsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
+	filter := struct {
+		fs.ListTicketsOptions
+		workspaceOptions
+		Query string `url:"query,omitempty"`
+	}{}
 
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	// quals the ticket filter API supports (such as status & priority) are sent as a filter query, the other filters
+	// aren't accepted alongside a query so the tickets are checked against them (and any other inexact qual) instead
+	path := "tickets"
+	if query := ticketFilterQuery(d); query != "" {
+		path = "tickets/filter"
+		filter.Query = query
+	} else {
+		q := d.EqualsQuals
 
-	q := d.EqualsQuals
-
-	if q["email"] != nil {
-		e := q["email"].GetStringValue()
-		filter.Email = &e
-	}
-
-	if q["requester_id"] != nil {
-		r := int(q["requester_id"].GetInt64Value())
-		filter.RequesterID = &r
-	}
-
-	if q["type"] != nil {
-		t := q["type"].GetStringValue()
-		filter.Type = &t
-	}
-
-	for {
-		tickets, res, err := client.Tickets.ListTickets(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_ticket.listTickets", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain tickets: %v", err)
+		if q["email"] != nil {
+			e := q["email"].GetStringValue()
+			filter.Email = &e
 		}
 
-		for _, ticket := range tickets.Collection {
-			d.StreamListItem(ctx, ticket)
+		if q["requester_id"] != nil {
+			r := int(q["requester_id"].GetInt64Value())
+			filter.RequesterID = &r
 		}
 
-		if res.Header.Get("link") == "" {
-			break
+		if q["type"] != nil {
+			t := q["type"].GetStringValue()
+			filter.Type = &t
 		}
 
-		filter.Page += 1
+		filter.UpdatedSince = updatedSince(d)
 	}
 
-	return nil, nil
-}
-
-// Transform Functions
-func ticketStatusDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
+	err = forEachWorkspace(ctx, d, func(workspaceID *int) error {
+		filter.WorkspaceID = workspaceID
+		return paginate(ctx, d, client, path, &filter, &filter.ListOptions, func(tickets *ticketItems) []ticketItem {
+			return matchingTickets(d, tickets.Collection)
+		})
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_ticket.listTickets", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain tickets: %w", err)
 	}
 
-	i := input.Value
-	switch i.(int) {
-	case 2:
-		return "Open", nil
-	case 3:
-		return "Pending", nil
-	case 4:
-		return "Resolved", nil
-	case 5:
-		return "Closed", nil
-	default:
-		return "Unknown", nil
-	}
+	return nil, nil
 }
 
-func ticketPriorityDesc(_ context.Context, input *transform.TransformData) (interface{}, error) {
-	if input.Value == nil {
-		return "Unknown", nil
-	}
-
-	i := input.Value
-	switch i.(int) {
-	case 1:
-		return "Low", nil
-	case 2:
-		return "Medium", nil
-	case 3:
-		return "High", nil
-	case 4:
-		return "Urgent", nil
-	default:
-		return "Unknown", nil
-	}
-}
+// Labels of the ticket fields which have a *_desc column, the defaults are used when the ticket form can't be read.
+var (
+	ticketStatus = formLabelField{Name: "status", Field: "Status", Defaults: map[int]string{
+		2: "Open", 3: "Pending", 4: "Resolved", 5: "Closed",
+	}}
+	ticketPriority = formLabelField{Name: "priority", Field: "Priority", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High", 4: "Urgent",
+	}}
+	ticketUrgency = formLabelField{Name: "urgency", Field: "Urgency", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High",
+	}}
+	ticketImpact = formLabelField{Name: "impact", Field: "Impact", Defaults: map[int]string{
+		1: "Low", 2: "Medium", 3: "High",
+	}}
+	ticketSource = formLabelField{Name: "source", Field: "Source", Defaults: map[int]string{
+		1: "Email", 2: "Portal", 3: "Phone", 4: "Chat", 5: "Feedback widget", 6: "Yammer", 7: "AWS Cloudwatch",
+		8: "Pagerduty", 9: "Walkup", 10: "Slack",
+	}}
+)
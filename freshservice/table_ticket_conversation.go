@@ -105,36 +105,14 @@ func listTicketConversations(ctx context.Context, d *plugin.QueryData, h *plugin
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListConversationsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListConversationsOptions{}
 
-	for {
-		conversations, res, err := client.Tickets.ListConversations(ticketId, &filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_ticket_conversation.listTicketConversations", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain conversations: %v", err)
-		}
-
-		for _, conversation := range conversations.Collection {
-			d.StreamListItem(ctx, conversation)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, fmt.Sprintf("tickets/%d/conversations", ticketId), &filter, &filter.ListOptions, func(conversations *fs.Conversations) []fs.Conversation {
+		return conversations.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_ticket_conversation.listTicketConversations", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain conversations: %w", err)
 	}
 
 	return nil, nil
@@ -108,36 +108,14 @@ func listTicketTasks(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListTasksOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListTasksOptions{}
 
-	for {
-		tasks, res, err := client.Tickets.ListTasks(ticketId, &filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_ticket_task.listTicketTasks", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain tasks: %v", err)
-		}
-
-		for _, task := range tasks.Collection {
-			d.StreamListItem(ctx, task)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, fmt.Sprintf("tickets/%d/tasks", ticketId), &filter, &filter.ListOptions, func(tasks *fs.Tasks) []fs.Task {
+		return tasks.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_ticket_task.listTicketTasks", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain tasks: %w", err)
 	}
 
 	return nil, nil
@@ -3,6 +3,7 @@ package freshservice
 import (
 	"context"
 	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -101,10 +102,11 @@ func listTicketTimeEntries(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	entries, _, err := client.Tickets.ListTimeEntries(ticketId)
+	entries := new(fs.TimeEntries)
+	_, err = client.list(ctx, fmt.Sprintf("tickets/%d/time_entries", ticketId), nil, entries)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_ticket_timeentry.listTicketTimeEntries", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain time entries: %v", err)
+		return nil, fmt.Errorf("unable to obtain time entries: %w", err)
 	}
 
 	for _, entry := range entries.Collection {
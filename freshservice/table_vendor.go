@@ -99,10 +99,11 @@ func getVendor(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	vendor, _, err := client.Vendors.GetVendor(id)
+	vendor := new(fs.Vendor)
+	err = client.get(ctx, fmt.Sprintf("vendors/%d", id), "vendor", vendor)
 	if err != nil {
 		plugin.Logger(ctx).Error("freshservice_vendor.getVendor", "query_error", err)
-		return nil, fmt.Errorf("unable to obtain vendor with id %d: %v", id, err)
+		return nil, fmt.Errorf("unable to obtain vendor with id %d: %w", id, err)
 	}
 
 	return vendor, nil
@@ -115,36 +116,14 @@ func listVendors(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
 	}
 
-	filter := fs.ListVendorsOptions{
-		ListOptions: fs.ListOptions{
-			Page:    1,
-			PerPage: 30,
-		},
-	}
-
-	limit := d.QueryContext.Limit
-	if limit != nil {
-		if *limit < int64(30) {
-			filter.PerPage = int(*limit)
-		}
-	}
+	filter := fs.ListVendorsOptions{}
 
-	for {
-		vendors, res, err := client.Vendors.ListVendors(&filter)
-		if err != nil {
-			plugin.Logger(ctx).Error("freshservice_vendor.listVendors", "query_error", err)
-			return nil, fmt.Errorf("unable to obtain vendors: %v", err)
-		}
-
-		for _, vendor := range vendors.Collection {
-			d.StreamListItem(ctx, vendor)
-		}
-
-		if res.Header.Get("link") == "" {
-			break
-		}
-
-		filter.Page += 1
+	err = paginate(ctx, d, client, "vendors", &filter, &filter.ListOptions, func(vendors *fs.Vendors) []fs.Vendor {
+		return vendors.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_vendor.listVendors", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain vendors: %w", err)
 	}
 
 	return nil, nil
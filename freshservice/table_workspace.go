@@ -0,0 +1,114 @@
+package freshservice
+
+import (
+	"context"
+	"fmt"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func tableWorkspace() *plugin.Table {
+	return &plugin.Table{
+		Name:        "freshservice_workspace",
+		Description: "Obtain information about Workspaces (such as IT, HR or Finance) within the FreshService instance.",
+		List: &plugin.ListConfig{
+			Hydrate: listWorkspaces,
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getWorkspace,
+			KeyColumns: plugin.SingleColumn("id"),
+		},
+		Columns: workspaceColumns(),
+	}
+}
+
+func workspaceColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "id",
+			Description: "ID of the workspace.",
+			Type:        proto.ColumnType_INT,
+		},
+		{
+			Name:        "name",
+			Description: "Name of the workspace.",
+			Type:        proto.ColumnType_STRING,
+		},
+		{
+			Name:        "description",
+			Description: "Description of the workspace.",
+			Type:        proto.ColumnType_STRING,
+		},
+		{
+			Name:        "state",
+			Description: "State of the workspace - (active, inactive).",
+			Type:        proto.ColumnType_STRING,
+		},
+		{
+			Name:        "primary",
+			Description: "Set to true if this is the primary workspace of the account, items are listed from the primary workspace unless a workspace_id is given.",
+			Type:        proto.ColumnType_BOOL,
+		},
+		{
+			Name:        "restricted",
+			Description: "Set to true if the workspace is only visible to its members.",
+			Type:        proto.ColumnType_BOOL,
+		},
+		{
+			Name:        "template_name",
+			Description: "Name of the template the workspace was created from (for example it, hr or finance).",
+			Type:        proto.ColumnType_STRING,
+		},
+		{
+			Name:        "created_at",
+			Description: "Timestamp when the workspace was created.",
+			Type:        proto.ColumnType_TIMESTAMP,
+		},
+		{
+			Name:        "updated_at",
+			Description: "Timestamp when the workspace was last updated.",
+			Type:        proto.ColumnType_TIMESTAMP,
+		},
+	}
+}
+
+// Hydrate Functions
+func getWorkspace(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := int(d.EqualsQuals["id"].GetInt64Value())
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_workspace.getWorkspace", "connection_error", err)
+		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
+	}
+
+	ws := new(workspace)
+	err = client.get(ctx, fmt.Sprintf("workspaces/%d", id), "workspace", ws)
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_workspace.getWorkspace", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain workspace with id %d: %w", id, err)
+	}
+
+	return ws, nil
+}
+
+func listWorkspaces(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_workspace.listWorkspaces", "connection_error", err)
+		return nil, fmt.Errorf("unable to create FreshService client: %v", err)
+	}
+
+	filter := fs.ListOptions{}
+
+	err = paginate(ctx, d, client, "workspaces", &filter, &filter, func(ws *workspaces) []workspace {
+		return ws.Collection
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice_workspace.listWorkspaces", "query_error", err)
+		return nil, fmt.Errorf("unable to obtain workspaces: %w", err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,293 @@
+package freshservice
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"strings"
+	"time"
+)
+
+// maxFilterQueryLength is the longest query accepted by the ticket filter API, including its enclosing quotes.
+const maxFilterQueryLength = 512
+
+// filterValueKind is the type of the values of a ticket filter field, which determines how quals are expressed.
+type filterValueKind int
+
+const (
+	filterInt filterValueKind = iota
+	filterDate
+	filterString
+)
+
+// ticketFilterField is a column of freshservice_ticket whose quals can be expressed as a condition of the ticket
+// filter API, on the filter field of the same name unless field is set. A listParam field is also accepted by the
+// list API, so a filter query isn't used for it alone.
+type ticketFilterField struct {
+	column    string
+	field     string
+	kind      filterValueKind
+	operators []string
+	listParam bool
+}
+
+var (
+	equalsOperators = []string{"="}
+	rangeOperators  = []string{"=", ">", ">=", "<", "<="}
+)
+
+var ticketFilterFields = []ticketFilterField{
+	{column: "status", kind: filterInt, operators: rangeOperators},
+	{column: "priority", kind: filterInt, operators: rangeOperators},
+	{column: "urgency", kind: filterInt, operators: rangeOperators},
+	{column: "impact", kind: filterInt, operators: rangeOperators},
+	{column: "responder_id", field: "agent_id", kind: filterInt, operators: equalsOperators},
+	{column: "group_id", kind: filterInt, operators: equalsOperators},
+	{column: "department_id", kind: filterInt, operators: equalsOperators},
+	{column: "tag", kind: filterString, operators: equalsOperators},
+	{column: "created_at", kind: filterDate, operators: rangeOperators},
+	{column: "due_by", kind: filterDate, operators: rangeOperators},
+	{column: "fr_due_by", kind: filterDate, operators: rangeOperators},
+	{column: "updated_at", kind: filterDate, operators: updatedSinceOperators, listParam: true},
+}
+
+// filterValueEscaper escapes a string value of a filter query, which is enclosed in single quotes within a query
+// enclosed in double quotes.
+var filterValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`)
+
+// ticketFilterKeyColumns returns the optional key columns of freshservice_ticket which are passed to the ticket filter
+// API.
+func ticketFilterKeyColumns() []*plugin.KeyColumn {
+	keyColumns := make([]*plugin.KeyColumn, 0, len(ticketFilterFields))
+	for _, f := range ticketFilterFields {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{
+			Name:      f.column,
+			Operators: f.operators,
+			Require:   plugin.Optional,
+		})
+	}
+
+	return keyColumns
+}
+
+// ticketFilterQuery returns the query of the ticket filter API for the quals of the ticket filter fields, such as
+// "priority:>3 AND status:2", or "" if there are none (or only quals the list API accepts). Dates are only compared
+// by day, so a range is widened to whole days (and Steampipe removes the extra rows). A query which would be too long
+// is not used, so that the tickets are listed instead.
+func ticketFilterQuery(d *plugin.QueryData) string {
+	var conditions []string
+	needed := false
+	for _, f := range ticketFilterFields {
+		kq := d.Quals[f.column]
+		if kq == nil {
+			continue
+		}
+
+		for _, q := range kq.Quals {
+			if condition, ok := f.condition(q.Operator, q.Value); ok {
+				conditions = append(conditions, condition)
+				needed = needed || !f.listParam
+			}
+		}
+	}
+
+	if !needed {
+		return ""
+	}
+
+	query := `"` + strings.Join(conditions, " AND ") + `"`
+	if len(query) > maxFilterQueryLength {
+		return ""
+	}
+
+	return query
+}
+
+// condition returns the filter condition of a qual, which is false if the qual can't be expressed.
+func (f ticketFilterField) condition(operator string, value *proto.QualValue) (string, bool) {
+	field := f.field
+	if field == "" {
+		field = f.column
+	}
+
+	// a list of values (from in or = any) is an OR of the conditions of each value
+	if list := value.GetListValue(); list != nil {
+		if operator != "=" || len(list.Values) == 0 {
+			return "", false
+		}
+
+		alternatives := make([]string, 0, len(list.Values))
+		for _, v := range list.Values {
+			c, ok := f.valueCondition(field, operator, v)
+			if !ok {
+				return "", false
+			}
+			alternatives = append(alternatives, c)
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", true
+	}
+
+	return f.valueCondition(field, operator, value)
+}
+
+// valueCondition returns the filter condition comparing field to a single value. The > & < operators of the ticket
+// filter API include the value, so exclusive integer bounds are adjusted by one.
+func (f ticketFilterField) valueCondition(field string, operator string, value *proto.QualValue) (string, bool) {
+	switch f.kind {
+	case filterInt:
+		v, ok := value.Value.(*proto.QualValue_Int64Value)
+		if !ok {
+			return "", false
+		}
+		i := v.Int64Value
+
+		switch operator {
+		case "=":
+			return fmt.Sprintf("%s:%d", field, i), true
+		case ">":
+			return fmt.Sprintf("%s:>%d", field, i+1), true
+		case ">=":
+			return fmt.Sprintf("%s:>%d", field, i), true
+		case "<":
+			return fmt.Sprintf("%s:<%d", field, i-1), true
+		case "<=":
+			return fmt.Sprintf("%s:<%d", field, i), true
+		}
+	case filterDate:
+		v, ok := value.Value.(*proto.QualValue_TimestampValue)
+		if !ok {
+			return "", false
+		}
+		day := v.TimestampValue.AsTime().UTC().Format("2006-01-02")
+
+		switch operator {
+		case "=":
+			return fmt.Sprintf("(%s:>'%s' AND %s:<'%s')", field, day, field, day), true
+		case ">", ">=":
+			return fmt.Sprintf("%s:>'%s'", field, day), true
+		case "<", "<=":
+			return fmt.Sprintf("%s:<'%s'", field, day), true
+		}
+	case filterString:
+		v, ok := value.Value.(*proto.QualValue_StringValue)
+		if !ok || operator != "=" {
+			return "", false
+		}
+
+		return fmt.Sprintf("%s:'%s'", field, filterValueEscaper.Replace(v.StringValue)), true
+	}
+
+	return "", false
+}
+
+// ticketColumnValues extract the value of each key column of freshservice_ticket (other than workspace_id) from a
+// ticket, the tags of a ticket match a tag qual if any of them is equal.
+var ticketColumnValues = map[string]func(t *ticketItem) interface{}{
+	"email":         func(t *ticketItem) interface{} { return t.Email },
+	"requester_id":  func(t *ticketItem) interface{} { return t.RequesterID },
+	"type":          func(t *ticketItem) interface{} { return t.Type },
+	"status":        func(t *ticketItem) interface{} { return t.Status },
+	"priority":      func(t *ticketItem) interface{} { return t.Priority },
+	"urgency":       func(t *ticketItem) interface{} { return t.Urgency },
+	"impact":        func(t *ticketItem) interface{} { return t.Impact },
+	"responder_id":  func(t *ticketItem) interface{} { return t.ResponderID },
+	"group_id":      func(t *ticketItem) interface{} { return t.GroupID },
+	"department_id": func(t *ticketItem) interface{} { return t.DepartmentID },
+	"tag":           func(t *ticketItem) interface{} { return t.Tags },
+	"created_at":    func(t *ticketItem) interface{} { return t.CreatedAt },
+	"due_by":        func(t *ticketItem) interface{} { return t.DueBy },
+	"fr_due_by":     func(t *ticketItem) interface{} { return t.FirstResponseDueBy },
+	"updated_at":    func(t *ticketItem) interface{} { return t.UpdatedAt },
+}
+
+// matchingTickets returns the tickets matching every qual of the query. Neither ticket API can express every qual
+// exactly (dates are compared by day, the filter API doesn't accept email, requester_id or type and the list API only
+// accepts those), yet Steampipe passes the limit of the query on as every qual is of a key column. Only streaming the
+// matching tickets ensures the limit isn't reached by tickets Postgres then removes.
+func matchingTickets(d *plugin.QueryData, tickets []ticketItem) []ticketItem {
+	matching := tickets[:0]
+	for i := range tickets {
+		if ticketMatches(d, &tickets[i]) {
+			matching = append(matching, tickets[i])
+		}
+	}
+
+	return matching
+}
+
+func ticketMatches(d *plugin.QueryData, t *ticketItem) bool {
+	for column, value := range ticketColumnValues {
+		kq := d.Quals[column]
+		if kq == nil {
+			continue
+		}
+
+		for _, q := range kq.Quals {
+			if !qualMatches(q.Operator, q.Value, value(t)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// qualMatches reports whether value satisfies a qual, a qual which can't be compared with value is left to Postgres.
+func qualMatches(operator string, qual *proto.QualValue, value interface{}) bool {
+	if list := qual.GetListValue(); list != nil {
+		for _, v := range list.Values {
+			if qualMatches(operator, v, value) {
+				return true
+			}
+		}
+		return false
+	}
+	if values, ok := value.([]string); ok {
+		for _, v := range values {
+			if qualMatches(operator, qual, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var order int
+	switch q := qual.Value.(type) {
+	case *proto.QualValue_Int64Value:
+		v, ok := value.(int)
+		if !ok {
+			return true
+		}
+		order = cmp.Compare(int64(v), q.Int64Value)
+	case *proto.QualValue_TimestampValue:
+		v, ok := value.(time.Time)
+		if !ok {
+			return true
+		}
+		order = v.Compare(q.TimestampValue.AsTime())
+	case *proto.QualValue_StringValue:
+		v, ok := value.(string)
+		if !ok {
+			return true
+		}
+		order = strings.Compare(v, q.StringValue)
+	default:
+		return true
+	}
+
+	switch operator {
+	case "=":
+		return order == 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	}
+
+	return true
+}
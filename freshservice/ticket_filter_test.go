@@ -0,0 +1,85 @@
+package freshservice
+
+import (
+	"testing"
+)
+
+func TestTicketFilterQuery(t *testing.T) {
+	p := startPlugin(t, "")
+
+	many := make([]int, 200)
+	for i := range many {
+		many[i] = i + 1000
+	}
+
+	tests := []struct {
+		name  string
+		quals quals
+		limit int64
+		path  string
+		query string
+		rows  int
+	}{
+		{name: "equals", quals: quals{"status": 2}, path: "tickets/filter", query: `"status:2"`, rows: 1},
+		{name: "at least", quals: quals{"priority": op{">=", 2}}, path: "tickets/filter", query: `"priority:>2"`, rows: 2},
+		{name: "greater than", quals: quals{"priority": op{">", 2}}, path: "tickets/filter", query: `"priority:>3"`, rows: 1},
+		{name: "less than", quals: quals{"urgency": op{"<", 3}}, path: "tickets/filter", query: `"urgency:<2"`, rows: 2},
+		// Steampipe lists the tickets for each value of a single in list, several lists are passed to the plugin
+		{name: "in", quals: quals{"status": []int{2, 6}, "priority": []int{1, 2}}, path: "tickets/filter", query: `"(status:2 OR status:6) AND (priority:1 OR priority:2)"`, rows: 2},
+		{name: "combined", quals: quals{"group_id": 17, "status": 5}, path: "tickets/filter", query: `"status:5 AND group_id:17"`, rows: 1},
+		{name: "agent", quals: quals{"responder_id": 1}, path: "tickets/filter", query: `"agent_id:1"`, rows: 1},
+		{name: "department", quals: quals{"department_id": 1, "impact": 1}, path: "tickets/filter", query: `"impact:1 AND department_id:1"`, rows: 1},
+		{name: "tag", quals: quals{"tag": "login"}, path: "tickets/filter", query: `"tag:'login'"`, rows: 1},
+		{name: "escaped tag", quals: quals{"tag": `it's "urgent"`}, path: "tickets/filter", query: `"tag:'it\'s \"urgent\"'"`, rows: 0},
+		{name: "created after", quals: quals{"created_at": op{">", ts("2023-01-05T12:00:00Z")}}, path: "tickets/filter", query: `"created_at:>'2023-01-05'"`, rows: 2},
+		{
+			name:  "created between",
+			quals: quals{"created_at": []op{{">=", ts("2023-01-01T00:00:00Z")}, {"<", ts("2023-01-31T00:00:00Z")}}},
+			path:  "tickets/filter",
+			query: `"created_at:>'2023-01-01' AND created_at:<'2023-01-31'"`,
+			rows:  2,
+		},
+		{name: "due on", quals: quals{"due_by": ts("2023-01-05T00:00:00Z")}, path: "tickets/filter", query: `"(due_by:>'2023-01-05' AND due_by:<'2023-01-05')"`, rows: 1},
+		// updated_at alone is sent to the list API as updated_since, alongside other quals it is part of the query
+		{name: "updated", quals: quals{"status": 5, "updated_at": op{">", ts("2023-03-01T00:00:00Z")}}, path: "tickets/filter", query: `"status:5 AND updated_at:>'2023-03-01'"`, rows: 1},
+		{name: "updated only", quals: quals{"updated_at": op{">", ts("2023-03-01T00:00:00Z")}}, path: "tickets", query: "", rows: 1},
+		// Steampipe passes the limit on as every qual is of a key column, so the tickets are checked against the quals
+		// the query can't express (or only expresses by day) before the limit is reached
+		{name: "with email", quals: quals{"email": "linus@example.com", "impact": 1}, limit: 1, path: "tickets/filter", query: `"impact:1"`, rows: 1},
+		{name: "with requester", quals: quals{"requester_id": 2, "urgency": op{"<", 3}}, limit: 1, path: "tickets/filter", query: `"urgency:<2"`, rows: 1},
+		{name: "updated same day", quals: quals{"status": 5, "updated_at": op{">", ts("2023-03-10T12:00:00Z")}}, limit: 1, path: "tickets/filter", query: `"status:5 AND updated_at:>'2023-03-10'"`, rows: 0},
+		{name: "unsupported", quals: quals{"email": "grace@example.com"}, path: "tickets", query: "", rows: 2},
+		{name: "too long", quals: quals{"status": many, "priority": []int{1, 2}}, path: "tickets", query: "", rows: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p.api.Reset()
+
+			rows := p.mustQuery(t, "freshservice_ticket", tt.quals, tt.limit)
+			if len(rows) != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+			for _, r := range rows {
+				if email, ok := tt.quals["email"]; ok && r["email"] != email {
+					t.Errorf("expected only tickets of %s, got %v", email, r["email"])
+				}
+				if requester, ok := tt.quals["requester_id"].(int); ok && r["requester_id"] != int64(requester) {
+					t.Errorf("expected only tickets of requester %d, got %v", requester, r["requester_id"])
+				}
+			}
+
+			requests := p.api.RequestsTo(tt.path)
+			if len(requests) == 0 {
+				t.Fatalf("expected a request to %s", tt.path)
+			}
+			if got := requests[0].Query().Get("query"); got != tt.query {
+				t.Errorf("expected query %s, got %s", tt.query, got)
+			}
+		})
+	}
+
+	// the tag column holds the tag the tickets were listed by
+	assertRow(t, p.mustQuery(t, "freshservice_ticket", quals{"tag": "login"}, 0), row{"id": int64(1), "tag": "login", "tags": []interface{}{"login"}})
+}
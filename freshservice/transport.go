@@ -0,0 +1,137 @@
+package freshservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	cleanHttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	sdkVersion "github.com/turbot/steampipe-plugin-sdk/v5/version"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const defaultRequestTimeout = 60 * time.Second
+
+// version of the plugin, set at build time with -ldflags "-X steampipe-plugin-freshservice/freshservice.version=x.y.z".
+var version = "dev"
+
+// userAgent identifies requests made by the plugin to FreshService.
+func userAgent() string {
+	return fmt.Sprintf("steampipe-plugin-freshservice/%s (Steampipe; steampipe-plugin-sdk/%s)", version, sdkVersion.String())
+}
+
+// newHttpClient creates the http client shared by every table of a connection, applying the proxy, timeout & TLS
+// settings of the PluginConfig.
+func newHttpClient(ctx context.Context, config PluginConfig) (*http.Client, error) {
+	transport := cleanHttp.DefaultPooledTransport()
+	transport.TLSClientConfig = &tls.Config{}
+
+	if config.HttpProxy != nil && *config.HttpProxy != "" {
+		proxy, err := parseProxyUrl(*config.HttpProxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if config.CaBundleFile != nil && *config.CaBundleFile != "" {
+		pool, err := loadCaBundle(*config.CaBundleFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if config.InsecureSkipVerify != nil && *config.InsecureSkipVerify {
+		plugin.Logger(ctx).Warn("freshservice.newHttpClient", "insecure_skip_verify", "the TLS certificate of FreshService will not be verified")
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	timeout := defaultRequestTimeout
+	if config.RequestTimeout != nil && *config.RequestTimeout > 0 {
+		timeout = time.Duration(*config.RequestTimeout) * time.Second
+	}
+
+	return &http.Client{
+		Transport: &timeoutTransport{base: transport, timeout: timeout},
+	}, nil
+}
+
+// timeoutTransport limits the time taken by each attempt at a request, from sending it to reading the whole response.
+// Unlike http.Client.Timeout this excludes the time spent waiting for the rate limiter.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, fmt.Errorf("request timed out after %s, see the request_timeout option: %v", t.timeout, err)
+		}
+		return nil, err
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+// cancelOnClose releases the context of a request once its response has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// parseProxyUrl parses the http_proxy option, errors never include the credentials of the proxy.
+func parseProxyUrl(raw string) (*url.URL, error) {
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse configuration option 'http_proxy', it must be a url such as 'http://proxy.my-corp.com:8080'")
+	}
+
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("configuration option 'http_proxy' must be a http, https or socks5 url, got '%s'", proxy.Redacted())
+	}
+
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("configuration option 'http_proxy' must include a host, got '%s'", proxy.Redacted())
+	}
+
+	return proxy, nil
+}
+
+// loadCaBundle returns the system certificate pool with the PEM encoded certificates of file added.
+func loadCaBundle(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ca_bundle_file '%s': %v", file, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ca_bundle_file '%s' does not contain any PEM encoded certificates", file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,51 @@
+package freshservice
+
+import (
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"time"
+)
+
+// updatedSinceOperators are the operators of an updated_at qual which can be passed to FreshService as the
+// updated_since parameter.
+var updatedSinceOperators = []string{">", ">="}
+
+// updatedSinceOptions lists the items updated since UpdatedSince, for the tables whose list options in go-freshservice
+// lack the updated_since parameter.
+type updatedSinceOptions struct {
+	UpdatedSince *time.Time `url:"updated_since,omitempty"`
+}
+
+// updatedAtKeyColumn is the optional updated_at key column of the tables whose list API accepts updated_since, so that
+// queries such as where updated_at > now() - interval '1 hour' only list the recently updated items.
+func updatedAtKeyColumn() *plugin.KeyColumn {
+	return &plugin.KeyColumn{
+		Name:      "updated_at",
+		Operators: updatedSinceOperators,
+		Require:   plugin.Optional,
+	}
+}
+
+// updatedSince returns the updated_since parameter for the updated_at quals of the query, the latest of their times,
+// or nil if there are none. updated_since includes items updated at that time, Steampipe removes them for a > qual.
+func updatedSince(d *plugin.QueryData) *time.Time {
+	kq := d.Quals["updated_at"]
+	if kq == nil {
+		return nil
+	}
+
+	var since *time.Time
+	for _, q := range kq.Quals {
+		v, ok := q.Value.Value.(*proto.QualValue_TimestampValue)
+		if !ok || (q.Operator != ">" && q.Operator != ">=") {
+			continue
+		}
+
+		t := v.TimestampValue.AsTime().UTC()
+		if since == nil || t.After(*since) {
+			since = &t
+		}
+	}
+
+	return since
+}
@@ -0,0 +1,49 @@
+package freshservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpdatedSince(t *testing.T) {
+	p := startPlugin(t, "")
+
+	tests := []struct {
+		table string
+		quals quals
+		since string
+		rows  int
+	}{
+		{table: "freshservice_ticket", quals: quals{"updated_at": op{">", ts("2023-03-01T00:00:00Z")}}, since: "2023-03-01T00:00:00Z", rows: 1},
+		{table: "freshservice_change", quals: quals{"updated_at": op{">=", ts("2023-03-10T12:00:00Z")}}, since: "2023-03-10T12:00:00Z", rows: 1},
+		// the later of several bounds is sent
+		{
+			table: "freshservice_problem",
+			quals: quals{"updated_at": []op{{">", ts("2023-01-01T00:00:00Z")}, {">=", ts("2023-02-04T00:00:00Z")}}},
+			since: "2023-02-04T00:00:00Z",
+			rows:  1,
+		},
+		{table: "freshservice_release", quals: quals{"updated_at": op{">", ts("2023-03-01T08:30:00Z")}}, since: "2023-03-01T08:30:00Z", rows: 1},
+		{table: "freshservice_release", quals: quals{}, since: "", rows: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.table, func(t *testing.T) {
+			p.api.Reset()
+
+			rows := p.mustQuery(t, tt.table, tt.quals, 0)
+			if len(rows) != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, len(rows))
+			}
+
+			requests := p.api.RequestsTo(strings.TrimPrefix(tt.table, "freshservice_") + "s")
+			if len(requests) != 1 {
+				t.Fatalf("expected a single list request, got %d", len(requests))
+			}
+			if got := requests[0].Query().Get("updated_since"); got != tt.since {
+				t.Errorf("expected updated_since %q, got %q", tt.since, got)
+			}
+		})
+	}
+}
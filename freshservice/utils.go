@@ -3,49 +3,166 @@ package freshservice
 import (
 	"context"
 	"fmt"
-	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/connection"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"net/url"
 	"os"
+	"strings"
 )
 
-func connect(ctx context.Context, d *plugin.QueryData) (*fs.Client, error) {
-	domain := os.Getenv("FRESHSERVICE_DOMAIN")
-	token := os.Getenv("FRESHSERVICE_TOKEN")
+// connect returns the apiClient for the connection, clients are cached in the connection cache (which is cleared when
+// the connection config changes) so that all hydrate calls share connection pooling, retries & rate limiting.
+func connect(ctx context.Context, d *plugin.QueryData) (*apiClient, error) {
+	return connectionClient(ctx, d.Connection, d.ConnectionCache)
+}
 
-	fsConfig := GetConfig(d.Connection)
-	if &fsConfig != nil {
-		if fsConfig.Domain != nil {
-			domain = *fsConfig.Domain
-		}
-		if fsConfig.Token != nil {
-			token = *fsConfig.Token
-		}
+// connectionClient is connect for use outside of a query, such as whilst the tables of a connection are loaded.
+func connectionClient(ctx context.Context, conn *plugin.Connection, cache *connection.ConnectionCache) (*apiClient, error) {
+	cacheKey := fmt.Sprintf("freshservice-client-%s", conn.Name)
+	if cached, ok := cache.Get(ctx, cacheKey); ok {
+		return cached.(*apiClient), nil
+	}
+
+	fsConfig := GetConfig(conn)
+	domain, baseUrl := connectionHost(fsConfig)
+
+	token, err := connectionToken(ctx, fsConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	if domain == "" || token == "" {
+	if (domain == "" && baseUrl == "") || token == nil {
 		errorMsg := ""
 
-		if domain == "" {
-			errorMsg += missingConfigOptionError("domain", "FRESHSERVICE_DOMAIN")
+		if domain == "" && baseUrl == "" {
+			errorMsg += "configuration option 'domain' (or 'base_url') or Environment Variable 'FRESHSERVICE_DOMAIN' (or 'FRESHSERVICE_BASE_URL') must be set.\n"
 		}
 
-		if token == "" {
+		if token == nil {
 			errorMsg += missingConfigOptionError("token", "FRESHSERVICE_TOKEN")
 		}
 
 		errorMsg += "please set the required values and restart Steampipe"
 
-		return new(fs.Client), fmt.Errorf(errorMsg)
+		return nil, fmt.Errorf(errorMsg)
+	}
+
+	apiUrl, err := apiBaseUrl(domain, baseUrl)
+	if err != nil {
+		return nil, err
 	}
 
-	api, err := fs.NewClient(ctx, domain, token)
+	api, err := newApiClient(ctx, apiUrl, token, fsConfig, connectionRateLimiter(conn.Name, fsConfig), connectionUsage(conn.Name))
 	if err != nil {
 		return nil, fmt.Errorf("error creating api client for FreshService: %v", err)
 	}
 
+	if err = cache.Set(ctx, cacheKey, api); err != nil {
+		plugin.Logger(ctx).Warn("connect", "cache_error", err)
+	}
+
 	return api, nil
 }
 
+// connectionHost returns the domain & base url of the connection, the environment variables are only read when neither
+// is configured so that a connection's config is never mixed with the host of another instance.
+func connectionHost(config PluginConfig) (domain string, baseUrl string) {
+	if config.Domain != nil || config.BaseUrl != nil {
+		if config.Domain != nil {
+			domain = *config.Domain
+		}
+		if config.BaseUrl != nil {
+			baseUrl = *config.BaseUrl
+		}
+		return domain, baseUrl
+	}
+
+	return os.Getenv("FRESHSERVICE_DOMAIN"), os.Getenv("FRESHSERVICE_BASE_URL")
+}
+
+// domainColumn is added to every table so that the rows of an aggregator connection can be told apart, ids are only
+// unique within a FreshService instance.
+func domainColumn() *plugin.Column {
+	return &plugin.Column{
+		Name:        "domain",
+		Description: "Host of the FreshService instance the row was obtained from (for example my-corp.freshservice.com), ids are only unique within an instance.",
+		Type:        proto.ColumnType_STRING,
+		Hydrate:     getDomain,
+		Transform:   transform.FromValue(),
+	}
+}
+
+// withDomainColumn adds domainColumn to table along with the declaration of getDomain, the SDK only initialises the retry
+// & ignore configs of declared hydrate functions and can't handle an error returned by any other.
+func withDomainColumn(table *plugin.Table) *plugin.Table {
+	table.Columns = append(table.Columns, domainColumn())
+	table.HydrateConfig = append(table.HydrateConfig, plugin.HydrateConfig{Func: getDomain})
+	return table
+}
+
+// getDomain returns the host of the FreshService instance of the connection, taken from the resolved base url.
+func getDomain(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := connect(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("freshservice.getDomain", "connection_error", err)
+		return nil, err
+	}
+
+	return client.baseUrl.Host, nil
+}
+
+// apiBaseUrl returns the url of the REST API, baseUrl (when set) takes precedence over the url derived from domain.
+func apiBaseUrl(domain string, baseUrl string) (*url.URL, error) {
+	if baseUrl != "" {
+		return parseBaseUrl(baseUrl)
+	}
+
+	host := normaliseDomain(domain)
+	if !strings.Contains(host, ".") {
+		host += ".freshservice.com"
+	}
+
+	return parseBaseUrl("https://" + host)
+}
+
+// normaliseDomain strips the scheme, path & whitespace from a domain pasted as a url such as
+// "https://my-corp.freshservice.com/", along with the ".freshservice.com" suffix.
+func normaliseDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+3:]
+	}
+	if i := strings.IndexAny(domain, "/?#"); i >= 0 {
+		domain = domain[:i]
+	}
+
+	return strings.TrimSuffix(domain, ".freshservice.com")
+}
+
+// parseBaseUrl parses the url of the REST API, a url without a path (such as "https://help.my-corp.com") is assumed
+// to be the root of a FreshService instance so the "/api/v2/" path is added.
+func parseBaseUrl(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse base url '%s': %v", raw, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("base url '%s' must be an absolute http or https url", raw)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/api/v2/"
+	} else if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u, nil
+}
+
 // missingConfigOptionError is a utility function for returning parts of error string
 func missingConfigOptionError(f string, ev string) string {
 	return fmt.Sprintf("configuration option '%s' or Environment Variable '%s' must be set.\n", f, ev)
@@ -0,0 +1,83 @@
+package freshservice
+
+import (
+	"testing"
+)
+
+func TestApiBaseUrl(t *testing.T) {
+	tests := []struct {
+		domain  string
+		baseUrl string
+		want    string
+		err     bool
+	}{
+		{domain: "my-corp", want: "https://my-corp.freshservice.com/api/v2/"},
+		{domain: " My-Corp ", want: "https://my-corp.freshservice.com/api/v2/"},
+		{domain: "my-corp.freshservice.com", want: "https://my-corp.freshservice.com/api/v2/"},
+		{domain: "https://my-corp.freshservice.com/", want: "https://my-corp.freshservice.com/api/v2/"},
+		{domain: "https://my-corp.freshservice.com/a/tickets/1", want: "https://my-corp.freshservice.com/api/v2/"},
+		{domain: "help.my-corp.com", want: "https://help.my-corp.com/api/v2/"},
+		{domain: "my-corp", baseUrl: "https://help.my-corp.com", want: "https://help.my-corp.com/api/v2/"},
+		{baseUrl: "https://help.my-corp.com/", want: "https://help.my-corp.com/api/v2/"},
+		{baseUrl: "http://localhost:8080/api/v2", want: "http://localhost:8080/api/v2/"},
+		{baseUrl: "http://127.0.0.1:8080/mock/api/v2/?x=1", want: "http://127.0.0.1:8080/mock/api/v2/"},
+		{baseUrl: "my-corp.freshservice.com", err: true},
+		{baseUrl: "ftp://my-corp.freshservice.com", err: true},
+		{domain: "my corp", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := apiBaseUrl(tt.domain, tt.baseUrl)
+		if tt.err {
+			if err == nil {
+				t.Errorf("apiBaseUrl(%q, %q): expected an error, got %s", tt.domain, tt.baseUrl, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("apiBaseUrl(%q, %q): unexpected error %v", tt.domain, tt.baseUrl, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("apiBaseUrl(%q, %q) = %s, expected %s", tt.domain, tt.baseUrl, got, tt.want)
+		}
+	}
+}
+
+func TestConnectionHost(t *testing.T) {
+	domain := "my-corp"
+	baseUrl := "https://help.my-corp.com"
+
+	t.Setenv("FRESHSERVICE_DOMAIN", "env-corp")
+	t.Setenv("FRESHSERVICE_BASE_URL", "https://help.env-corp.com")
+
+	tests := []struct {
+		config  PluginConfig
+		domain  string
+		baseUrl string
+		want    string
+	}{
+		{config: PluginConfig{}, domain: "env-corp", baseUrl: "https://help.env-corp.com", want: "https://help.env-corp.com/api/v2/"},
+		{config: PluginConfig{Domain: &domain}, domain: "my-corp", want: "https://my-corp.freshservice.com/api/v2/"},
+		{config: PluginConfig{BaseUrl: &baseUrl}, baseUrl: "https://help.my-corp.com", want: "https://help.my-corp.com/api/v2/"},
+		{config: PluginConfig{Domain: &domain, BaseUrl: &baseUrl}, domain: "my-corp", baseUrl: "https://help.my-corp.com", want: "https://help.my-corp.com/api/v2/"},
+	}
+
+	for _, tt := range tests {
+		gotDomain, gotBaseUrl := connectionHost(tt.config)
+		if gotDomain != tt.domain || gotBaseUrl != tt.baseUrl {
+			t.Errorf("connectionHost(%+v) = %q, %q, expected %q, %q", tt.config, gotDomain, gotBaseUrl, tt.domain, tt.baseUrl)
+			continue
+		}
+
+		got, err := apiBaseUrl(gotDomain, gotBaseUrl)
+		if err != nil {
+			t.Errorf("apiBaseUrl(%q, %q): unexpected error %v", gotDomain, gotBaseUrl, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("apiBaseUrl(%q, %q) = %s, expected %s", gotDomain, gotBaseUrl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package freshservice
+
+import (
+	"context"
+	fs "github.com/theapsgroup/go-freshservice/freshservice"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"time"
+)
+
+// workspace is a workspace of a FreshService account with Enterprise Service Management, such as IT, HR or Finance.
+type workspace struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	State        string    `json:"state"`
+	Primary      bool      `json:"primary"`
+	Restricted   bool      `json:"restricted"`
+	TemplateName string    `json:"template_name"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type workspaces struct {
+	Collection []workspace `json:"workspaces"`
+}
+
+// The items of workspace scoped tables, go-freshservice doesn't decode the workspace an item belongs to. The items of
+// tickets, changes, problems & releases are in custom_fields.go, the items of assets in asset_type_tables.go.
+
+type announcementItem struct {
+	fs.Announcement
+	WorkspaceID *int `json:"workspace_id"`
+}
+
+type announcementItems struct {
+	Collection []announcementItem `json:"announcements"`
+}
+
+type serviceItem struct {
+	fs.ServiceItem
+	WorkspaceID *int `json:"workspace_id"`
+}
+
+type serviceItems struct {
+	Collection []serviceItem `json:"service_items"`
+}
+
+type solutionCategoryItem struct {
+	fs.SolutionCategory
+	WorkspaceID *int `json:"workspace_id"`
+}
+
+type solutionCategoryItems struct {
+	Collection []solutionCategoryItem `json:"categories"`
+}
+
+// workspaceOptions restricts a list to a single workspace, it is embedded in the list options of workspace scoped
+// tables. FreshService returns the items of the primary workspace when no workspace is given, or the items of every
+// workspace the agent can access for workspace 0.
+type workspaceOptions struct {
+	WorkspaceID *int `url:"workspace_id,omitempty"`
+}
+
+// workspaceIdColumn is the workspace_id column of workspace scoped tables.
+func workspaceIdColumn() *plugin.Column {
+	return &plugin.Column{
+		Name:        "workspace_id",
+		Description: "ID of the workspace the item belongs to, null for accounts without workspaces.",
+		Type:        proto.ColumnType_INT,
+	}
+}
+
+// workspaceIdKeyColumn allows workspace scoped tables to be listed for specific workspaces (where workspace_id = 2 or
+// workspace_id in (2, 3)).
+func workspaceIdKeyColumn() *plugin.KeyColumn {
+	return &plugin.KeyColumn{
+		Name:    "workspace_id",
+		Require: plugin.Optional,
+	}
+}
+
+// forEachWorkspace calls list for every workspace a query covers, which are the workspaces in the workspace_id qual or
+// else the workspace_ids of the connection. list is called once with nil (the primary workspace) when neither is set.
+func forEachWorkspace(ctx context.Context, d *plugin.QueryData, list func(workspaceID *int) error) error {
+	ids := GetConfig(d.Connection).WorkspaceIds
+
+	if q := d.EqualsQuals["workspace_id"]; q != nil {
+		ids = nil
+		if l := q.GetListValue(); l != nil {
+			for _, v := range l.Values {
+				ids = append(ids, int(v.GetInt64Value()))
+			}
+		} else {
+			ids = append(ids, int(q.GetInt64Value()))
+		}
+	}
+
+	if len(ids) == 0 {
+		return list(nil)
+	}
+
+	for _, id := range ids {
+		id := id
+		if err := list(&id); err != nil {
+			return err
+		}
+
+		if d.RowsRemaining(ctx) <= 0 {
+			return nil
+		}
+	}
+
+	return nil
+}